@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the favctl configuration file, by default
+// $XDG_CONFIG_HOME/favctl/config.yaml:
+//
+//	current: local
+//	profiles:
+//	  local:
+//	    server: http://localhost:8080
+//	  prod:
+//	    server: https://favorites.example.com
+//	    adminToken: s3cret
+//	    actor: support
+type Config struct {
+	Current  string             `yaml:"current"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Profile is a server and the credentials used with it.
+type Profile struct {
+	Server     string `yaml:"server"`
+	AdminToken string `yaml:"adminToken,omitempty"`
+	Actor      string `yaml:"actor,omitempty"` // Sent as X-Actor to attribute changes; requires adminToken
+}
+
+// defaultServer is used when there is no config file.
+const defaultServer = "http://localhost:8080"
+
+// defaultConfigPath returns the config file used without -config.
+func defaultConfigPath() string {
+	if p := os.Getenv("FAVCTL_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "favctl", "config.yaml")
+}
+
+// loadProfile reads the named profile from the config file at path, or the
+// file's current profile if name is empty. A missing default config file
+// yields a profile for defaultServer.
+func loadProfile(path, name string, explicitPath bool) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicitPath && name == "" {
+		return Profile{Server: defaultServer}, nil
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return Profile{}, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	if name == "" {
+		name = cfg.Current
+	}
+	if name == "" && len(cfg.Profiles) == 1 {
+		for only := range cfg.Profiles {
+			name = only
+		}
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(cfg.Profiles))
+		for n := range cfg.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Profile{}, fmt.Errorf("no profile %q in %s (have %v)", name, path, names)
+	}
+	if p.Server == "" {
+		return Profile{}, fmt.Errorf("profile %q has no server", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,378 @@
+// Command favctl manages users' favorites through the favorites API, for
+// support and debugging. The server and credentials come from a profile in
+// the config file (see Config); -server overrides the profile's server.
+//
+// Usage:
+//
+//	favctl list   USER [-include-unavailable]
+//	favctl add    USER ASSET [-d DESCRIPTION]
+//	favctl edit   USER ASSET -d DESCRIPTION
+//	favctl remove USER ASSET [-strict]
+//	favctl search [TEXT] [-type TYPE] [-for-user USER]
+//	favctl export USER [-format csv|json|ndjson] [-file PATH]
+//	favctl import USER FILE [-format csv|json|ndjson] [-policy skip|overwrite|fail] [-dry-run]
+//
+// Every command accepts -profile NAME, -config PATH, -server URL and
+// -o table|json|yaml.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"my-solution/internal/export"
+	"my-solution/internal/models"
+	"my-solution/pkg/client"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "favctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: favctl <list|add|edit|remove|search|export|import> [args] [flags]")
+}
+
+// command is a parsed favctl invocation.
+type command struct {
+	name   string
+	args   []string // Positional arguments
+	out    io.Writer
+	format string
+	client *client.Client
+
+	// Command-specific flags
+	description        string
+	descriptionSet     bool
+	includeUnavailable bool
+	strict             bool
+	assetType          string
+	forUser            string
+	fileFormat         string
+	file               string
+	policy             string
+	dryRun             bool
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return errors.New("missing command")
+	}
+	cmd := command{name: args[0], out: out}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	profileName := fs.String("profile", os.Getenv("FAVCTL_PROFILE"), "config profile (default $FAVCTL_PROFILE or the config's current profile)")
+	configPath := fs.String("config", "", "config file (default $FAVCTL_CONFIG or ~/.config/favctl/config.yaml)")
+	server := fs.String("server", "", "server URL, overriding the profile")
+	fs.StringVar(&cmd.format, "o", formatTable, "output format: table, json or yaml")
+	fs.StringVar(&cmd.description, "d", "", "add, edit: favorite description")
+	fs.BoolVar(&cmd.includeUnavailable, "include-unavailable", false, "list: include favorites whose asset left the catalog")
+	fs.BoolVar(&cmd.strict, "strict", false, "remove: fail if the asset is not favorited")
+	fs.StringVar(&cmd.assetType, "type", "", "search: only assets of this type (chart, insight, audience)")
+	fs.StringVar(&cmd.forUser, "for-user", "", "search: mark the assets this user has favorited")
+	fs.StringVar(&cmd.fileFormat, "format", "", "export, import: file format (default json, or the import file's extension)")
+	fs.StringVar(&cmd.file, "file", "", "export: write to this file instead of stdout")
+	fs.StringVar(&cmd.policy, "policy", "", "import: what to do with assets already favorited (skip, overwrite, fail)")
+	fs.BoolVar(&cmd.dryRun, "dry-run", false, "import: report what would change without writing")
+
+	var err error
+	if cmd.args, err = parseInterspersed(fs, args[1:]); err != nil {
+		return err
+	}
+	fs.Visit(func(f *flag.Flag) { cmd.descriptionSet = cmd.descriptionSet || f.Name == "d" })
+	if !validFormat(cmd.format) {
+		return fmt.Errorf("invalid -o %q: must be table, json or yaml", cmd.format)
+	}
+
+	path := *configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	profile, err := loadProfile(path, *profileName, *configPath != "")
+	if err != nil {
+		return err
+	}
+	if *server != "" {
+		profile.Server = *server
+	}
+	if cmd.client, err = client.New(profile.Server, client.Options{AdminToken: profile.AdminToken, Actor: profile.Actor}); err != nil {
+		return err
+	}
+
+	switch cmd.name {
+	case "list":
+		return cmd.list(ctx)
+	case "add":
+		return cmd.add(ctx)
+	case "edit":
+		return cmd.edit(ctx)
+	case "remove":
+		return cmd.remove(ctx)
+	case "search":
+		return cmd.search(ctx)
+	case "export":
+		return cmd.export(ctx)
+	case "import":
+		return cmd.importFile(ctx)
+	default:
+		usage(os.Stderr)
+		return fmt.Errorf("unknown command %q", cmd.name)
+	}
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments, which it returns.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// want checks the number of positional arguments.
+func (c *command) want(names ...string) error {
+	if len(c.args) != len(names) {
+		return fmt.Errorf("usage: favctl %s %s", c.name, strings.ToUpper(strings.Join(names, " ")))
+	}
+	return nil
+}
+
+func (c *command) list(ctx context.Context) error {
+	if err := c.want("user"); err != nil {
+		return err
+	}
+	favs, err := c.client.ListFavorites(ctx, c.args[0], client.ListFavoritesOptions{IncludeUnavailable: c.includeUnavailable})
+	if err != nil {
+		return err
+	}
+	return c.renderFavorites(favs)
+}
+
+func (c *command) renderFavorites(favs []models.FavoriteWithAsset) error {
+	t := table{header: []string{"ASSET", "TYPE", "NAME", "DESCRIPTION", "CREATED", "STATUS"}}
+	for _, f := range favs {
+		var typ, name string
+		if f.Asset != nil {
+			typ, name = models.AssetType(f.Asset), f.Asset.GetName()
+		}
+		t.rows = append(t.rows, []string{f.AssetID, typ, cell(name), cell(f.Description), formatTime(f.CreatedAt), f.Status})
+	}
+	return render(c.out, c.format, favs, t)
+}
+
+func (c *command) add(ctx context.Context) error {
+	if err := c.want("user", "asset"); err != nil {
+		return err
+	}
+	userID, assetID := c.args[0], c.args[1]
+	if err := c.client.AddFavorite(ctx, userID, assetID, c.description); err != nil {
+		return err
+	}
+	return c.showFavorite(ctx, userID, assetID)
+}
+
+func (c *command) edit(ctx context.Context) error {
+	if err := c.want("user", "asset"); err != nil {
+		return err
+	}
+	if !c.descriptionSet {
+		return errors.New("edit: -d is required")
+	}
+	userID, assetID := c.args[0], c.args[1]
+	if err := c.client.EditFavorite(ctx, userID, assetID, c.description); err != nil {
+		return err
+	}
+	return c.showFavorite(ctx, userID, assetID)
+}
+
+// showFavorite prints a favorite after a change.
+func (c *command) showFavorite(ctx context.Context, userID, assetID string) error {
+	fav, err := c.client.GetFavorite(ctx, userID, assetID)
+	if err != nil {
+		return err
+	}
+	if c.format != formatTable {
+		return render(c.out, c.format, fav, table{})
+	}
+	return c.renderFavorites([]models.FavoriteWithAsset{fav})
+}
+
+// remove prints nothing on success.
+func (c *command) remove(ctx context.Context) error {
+	if err := c.want("user", "asset"); err != nil {
+		return err
+	}
+	return c.client.RemoveFavorite(ctx, c.args[0], c.args[1], client.RemoveFavoriteOptions{Strict: c.strict})
+}
+
+// search lists catalog assets whose ID, name or description contains the
+// text, case-insensitively. Without text it lists the whole catalog.
+func (c *command) search(ctx context.Context) error {
+	if len(c.args) > 1 {
+		return errors.New("usage: favctl search [TEXT]")
+	}
+	if c.assetType != "" && !models.ValidAssetType(c.assetType) {
+		return fmt.Errorf("invalid -type %q: must be chart, insight or audience", c.assetType)
+	}
+	var text string
+	if len(c.args) == 1 {
+		text = strings.ToLower(c.args[0])
+	}
+
+	assets, err := c.client.ListAssets(ctx, client.ListAssetsOptions{ForUser: c.forUser})
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		Type                string       `json:"type"`
+		Asset               models.Asset `json:"asset"`
+		IsFavorite          *bool        `json:"isFavorite,omitempty"`
+		FavoriteDescription string       `json:"favoriteDescription,omitempty"`
+	}
+	results := []result{}
+	header := []string{"ID", "TYPE", "NAME", "DESCRIPTION"}
+	if c.forUser != "" {
+		header = append(header, "FAVORITE")
+	}
+	t := table{header: header}
+	for _, a := range assets {
+		typ := models.AssetType(a.Asset)
+		if c.assetType != "" && typ != c.assetType {
+			continue
+		}
+		if text != "" && !matches(a.Asset, text) {
+			continue
+		}
+		r := result{Type: typ, Asset: a.Asset}
+		row := []string{a.Asset.GetID(), typ, cell(a.Asset.GetName()), cell(a.Asset.GetDescription())}
+		if c.forUser != "" {
+			r.IsFavorite, r.FavoriteDescription = &a.IsFavorite, a.FavoriteDescription
+			row = append(row, yesNo(a.IsFavorite))
+		}
+		results = append(results, r)
+		t.rows = append(t.rows, row)
+	}
+	return render(c.out, c.format, results, t)
+}
+
+func matches(a models.Asset, text string) bool {
+	for _, s := range []string{a.GetID(), a.GetName(), a.GetDescription()} {
+		if strings.Contains(strings.ToLower(s), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// export writes the server's export file unchanged, so it can be imported
+// again; -o does not apply.
+func (c *command) export(ctx context.Context) error {
+	if err := c.want("user"); err != nil {
+		return err
+	}
+	format := export.JSON
+	if c.fileFormat != "" {
+		var err error
+		if format, err = export.ParseFormat(c.fileFormat); err != nil {
+			return err
+		}
+	}
+
+	body, err := c.client.ExportFavorites(ctx, c.args[0], format)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if c.file == "" {
+		_, err = io.Copy(c.out, body)
+		return err
+	}
+	f, err := os.Create(c.file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (c *command) importFile(ctx context.Context) error {
+	if err := c.want("user", "file"); err != nil {
+		return err
+	}
+	userID, path := c.args[0], c.args[1]
+
+	name := c.fileFormat
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := export.ParseFormat(name)
+	if err != nil {
+		return fmt.Errorf("cannot tell the format of %s, use -format: %w", path, err)
+	}
+	opts := client.ImportOptions{Format: format, DryRun: c.dryRun}
+	if c.policy != "" {
+		if opts.Policy, err = export.ParsePolicy(c.policy); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, importErr := c.client.ImportFavorites(ctx, userID, f, opts)
+	if importErr != nil && !errors.Is(importErr, client.ErrConflict) {
+		return importErr
+	}
+	t := table{header: []string{"ROW", "ASSET", "ACTION", "ERROR"}}
+	for _, r := range report.Rows {
+		t.rows = append(t.rows, []string{fmt.Sprint(r.Row), r.AssetID, string(r.Action), cell(r.Error)})
+	}
+	if err := render(c.out, c.format, report, t); err != nil {
+		return err
+	}
+	if c.format == formatTable {
+		fmt.Fprintf(c.out, "\nadded %d, overwritten %d, skipped %d, conflicts %d, invalid %d",
+			report.Added, report.Overwritten, report.Skipped, report.Conflicts, report.Invalid)
+		if report.DryRun {
+			fmt.Fprint(c.out, " (dry run)")
+		}
+		fmt.Fprintln(c.out)
+	}
+	// Conflicts under -policy fail abort the import; report them and fail.
+	return importErr
+}
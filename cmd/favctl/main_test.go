@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"my-solution/internal/api"
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// setup serves the real API and writes a config file whose current
+// profile points at it.
+func setup(t *testing.T) (configPath string) {
+	t.Helper()
+	catalog.Initialize()
+	catalog.Global.AddAsset("c1", models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Revenue", Description: "Quarterly revenue"}, ChartType: "bar"})
+	catalog.Global.AddAsset("a1", models.Audience{AssetBase: models.AssetBase{ID: "a1", Name: "Gen Z"}, Segment: "18-24", Size: 100})
+
+	r := mux.NewRouter()
+	(&api.API{Store: store.NewMemoryStore()}).RegisterHandlers(r)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	configPath = filepath.Join(t.TempDir(), "config.yaml")
+	cfg := "current: test\nprofiles:\n  test:\n    server: " + srv.URL + "\n  other:\n    server: http://127.0.0.1:1\n"
+	if err := os.WriteFile(configPath, []byte(cfg), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return configPath
+}
+
+func favctl(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	err := run(context.Background(), args, &out)
+	return out.String(), err
+}
+
+func TestFavoriteCommands(t *testing.T) {
+	cfg := setup(t)
+
+	out, err := favctl(t, "add", "u1", "c1", "-d", "my chart", "-config", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "ASSET") || !strings.Contains(out, "my chart") {
+		t.Errorf("unexpected table output:\n%s", out)
+	}
+	if _, err := favctl(t, "-config", cfg, "add", "u1", "a1"); err == nil {
+		t.Error("expected flags before the command to be rejected")
+	}
+	if _, err := favctl(t, "add", "-config", cfg, "u1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := favctl(t, "edit", "u1", "a1", "-config", cfg); err == nil {
+		t.Error("expected edit without -d to fail")
+	}
+	if _, err := favctl(t, "edit", "u1", "a1", "-d", "", "-config", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = favctl(t, "list", "u1", "-o", "json", "-config", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var favs []map[string]any
+	if err := json.Unmarshal([]byte(out), &favs); err != nil || len(favs) != 2 {
+		t.Fatalf("unexpected JSON output %q: %v", out, err)
+	}
+
+	out, err = favctl(t, "list", "u1", "-o", "yaml", "-config", cfg)
+	if err != nil || !strings.Contains(out, "assetId: c1") || !strings.Contains(out, "Segment: 18-24") {
+		t.Errorf("unexpected YAML output %q: %v", out, err)
+	}
+
+	if _, err := favctl(t, "remove", "u1", "c1", "-strict", "-config", cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := favctl(t, "remove", "u1", "c1", "-strict", "-config", cfg); err == nil {
+		t.Error("expected strict remove of a missing favorite to fail")
+	}
+
+	if _, err := favctl(t, "list", "u1", "-profile", "other", "-config", cfg); err == nil {
+		t.Error("expected the other profile's server to be unreachable")
+	}
+	if _, err := favctl(t, "list", "u1", "-profile", "missing", "-config", cfg); err == nil {
+		t.Error("expected an unknown profile to fail")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	cfg := setup(t)
+	favctl(t, "add", "u1", "a1", "-config", cfg)
+
+	out, err := favctl(t, "search", "REVENUE", "-config", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "c1") || strings.Contains(out, "a1") {
+		t.Errorf("unexpected search output:\n%s", out)
+	}
+
+	out, err = favctl(t, "search", "-type", "audience", "-for-user", "u1", "-o", "json", "-config", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []struct {
+		Type       string
+		IsFavorite *bool
+	}
+	if err := json.Unmarshal([]byte(out), &results); err != nil || len(results) != 1 {
+		t.Fatalf("unexpected search output %q: %v", out, err)
+	}
+	if r := results[0]; r.Type != "audience" || r.IsFavorite == nil || !*r.IsFavorite {
+		t.Errorf("unexpected result %+v", r)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	cfg := setup(t)
+	favctl(t, "add", "u1", "c1", "-d", "note", "-config", cfg)
+	favctl(t, "add", "u1", "a1", "-config", cfg)
+
+	file := filepath.Join(t.TempDir(), "favorites.csv")
+	if _, err := favctl(t, "export", "u1", "-format", "csv", "-file", file, "-config", cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := favctl(t, "import", "u2", file, "-dry-run", "-config", cfg)
+	if err != nil || !strings.Contains(out, "added 2") || !strings.Contains(out, "(dry run)") {
+		t.Errorf("unexpected dry run output %q: %v", out, err)
+	}
+	if _, err := favctl(t, "import", "u2", file, "-config", cfg); err != nil {
+		t.Fatal(err)
+	}
+	out, err = favctl(t, "import", "u2", file, "-policy", "fail", "-o", "json", "-config", cfg)
+	if err == nil || !strings.Contains(out, `"conflicts": 2`) {
+		t.Errorf("expected conflicts to be reported and fail, got %q: %v", out, err)
+	}
+}
@@ -0,0 +1,127 @@
+// Command favlog inspects and maintains the favorites event log used when
+// the server runs with EVENT_LOG_DIR. Stop the server before truncating.
+//
+// Usage:
+//
+//	favlog inspect  -dir DIR
+//	favlog replay   -dir DIR [-from N] [-user ID]
+//	favlog truncate -dir DIR -offset N
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"my-solution/internal/eventlog"
+	"my-solution/internal/events"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "favlog:", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: favlog <inspect|replay|truncate> -dir DIR [flags]")
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return fmt.Errorf("missing command")
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	dir := fs.String("dir", os.Getenv("EVENT_LOG_DIR"), "event log directory (default $EVENT_LOG_DIR)")
+	from := fs.Uint64("from", 0, "replay: first offset to print")
+	user := fs.String("user", "", "replay: only print records for this user")
+	offset := fs.Int64("offset", -1, "truncate: discard records at or after this offset")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("-dir is required")
+	}
+
+	// Only truncate may change the log; inspect and replay must be safe to
+	// run against the directory of a live server.
+	var (
+		l   *eventlog.Log
+		err error
+	)
+	if args[0] == "truncate" {
+		l, err = eventlog.Open(*dir, eventlog.Options{})
+	} else {
+		l, err = eventlog.OpenReadOnly(*dir)
+	}
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	switch args[0] {
+	case "inspect":
+		return inspect(l, out)
+	case "replay":
+		return replay(l, out, *from, *user)
+	case "truncate":
+		if *offset < 0 {
+			return fmt.Errorf("-offset is required")
+		}
+		before := l.NextOffset()
+		if err := l.Truncate(uint64(*offset)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "discarded %d records, next offset %d\n", before-l.NextOffset(), l.NextOffset())
+		return nil
+	default:
+		usage(os.Stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// inspect prints the log's segments and a summary of its records.
+func inspect(l *eventlog.Log, out io.Writer) error {
+	for _, seg := range l.Segments() {
+		fmt.Fprintf(out, "segment %s  base=%d  records=%d  bytes=%d\n", seg.Path, seg.Base, seg.Count, seg.Size)
+	}
+
+	types := make(map[events.Type]int)
+	users := make(map[string]bool)
+	err := l.Read(0, func(r eventlog.Record) error {
+		types[r.Type]++
+		users[r.UserID] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "records: %d  users: %d\n", l.NextOffset(), len(users))
+	names := make([]string, 0, len(types))
+	for t := range types {
+		names = append(names, string(t))
+	}
+	sort.Strings(names)
+	for _, t := range names {
+		fmt.Fprintf(out, "  %-30s %d\n", t, types[events.Type(t)])
+	}
+	return nil
+}
+
+// replay prints records from offset from as JSON lines.
+func replay(l *eventlog.Log, out io.Writer, from uint64, user string) error {
+	enc := json.NewEncoder(out)
+	return l.Read(from, func(r eventlog.Record) error {
+		if user != "" && r.UserID != user {
+			return nil
+		}
+		return enc.Encode(r)
+	})
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"my-solution/internal/eventlog"
+	"my-solution/internal/events"
+)
+
+// writeLog appends n added records for u1 and one for u2 to a new log and
+// returns its directory and only segment.
+func writeLog(t *testing.T, n int) (dir, segment string) {
+	t.Helper()
+	dir = t.TempDir()
+	l, err := eventlog.Open(dir, eventlog.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if _, err := l.Append(eventlog.Record{Type: events.FavoriteAdded, UserID: "u1", AssetID: "a"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := l.Append(eventlog.Record{Type: events.FavoriteRemoved, UserID: "u2", AssetID: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	segment = l.Segments()[0].Path
+	l.Close()
+	return dir, segment
+}
+
+func favlog(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	err := run(args, &out)
+	return out.String(), err
+}
+
+func TestInspect(t *testing.T) {
+	dir, _ := writeLog(t, 2)
+
+	out, err := favlog(t, "inspect", "-dir", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "records: 3  users: 2") || !strings.Contains(out, string(events.FavoriteAdded)) {
+		t.Errorf("unexpected inspect output:\n%s", out)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	dir, _ := writeLog(t, 2)
+
+	out, err := favlog(t, "replay", "-dir", dir, "-from", "1", "-user", "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one record, got:\n%s", out)
+	}
+	var r eventlog.Record
+	if err := json.Unmarshal([]byte(lines[0]), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Offset != 1 || r.UserID != "u1" {
+		t.Errorf("unexpected record %+v", r)
+	}
+}
+
+func TestReadCommandsLeaveLogUnchanged(t *testing.T) {
+	dir, segment := writeLog(t, 2)
+
+	// A record the server is still writing.
+	f, _ := os.OpenFile(segment, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"offset":3,"type":"favor`)
+	f.Close()
+	before, _ := os.ReadFile(segment)
+
+	for _, cmd := range []string{"inspect", "replay"} {
+		if _, err := favlog(t, cmd, "-dir", dir); err != nil {
+			t.Fatalf("%s: %v", cmd, err)
+		}
+		if after, _ := os.ReadFile(segment); !bytes.Equal(after, before) {
+			t.Fatalf("%s changed the segment from %d to %d bytes", cmd, len(before), len(after))
+		}
+	}
+
+	missing := dir + "/missing"
+	if _, err := favlog(t, "inspect", "-dir", missing); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Errorf("inspect created %s", missing)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	dir, _ := writeLog(t, 2)
+
+	if _, err := favlog(t, "truncate", "-dir", dir); err == nil {
+		t.Error("expected error without -offset")
+	}
+	out, err := favlog(t, "truncate", "-dir", dir, "-offset", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "discarded 2 records, next offset 1") {
+		t.Errorf("unexpected truncate output: %s", out)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	dir, _ := writeLog(t, 1)
+	if _, err := favlog(t, "compact", "-dir", dir); err == nil {
+		t.Error("expected error for unknown command")
+	}
+}
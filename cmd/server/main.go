@@ -1,29 +1,59 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"time"
 
-	_ "my-solution/docs" // docs is generated by Swag CLI
+	_ "my-solution/docs"    // docs is generated by Swag CLI
+	_ "my-solution/docs/v2" // v2 docs, instance "v2"
+	"my-solution/internal/aliases"
 	"my-solution/internal/api"
+	"my-solution/internal/apiv2"
 	"my-solution/internal/catalog"
+	"my-solution/internal/dashboard"
+	"my-solution/internal/eventlog"
+	"my-solution/internal/events"
+	"my-solution/internal/gdpr"
+	"my-solution/internal/history"
+	"my-solution/internal/orphans"
+	"my-solution/internal/recommend"
 	"my-solution/internal/store"
+	"my-solution/internal/webhooks"
 
 	"github.com/gorilla/mux"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/grpc"
 )
 
 // @title Favorites API
 // @version 0.1
 // @description API for managing user's favorite assets
 // @host localhost:8080
-// @BasePath /
+// @BasePath /v1
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin bearer token, e.g. "Bearer <ADMIN_TOKEN>"
 func main() {
 	// Get configuration from environment
 	port := getEnv("PORT", "8080")
+	grpcPort := getEnv("GRPC_PORT", "9090")
 	catalogPath := getEnv("CATALOG_PATH", "sample_data/seed_assets.json")
 	instanceID := getEnv("INSTANCE_ID", "default")
+	adminToken := getEnv("ADMIN_TOKEN", "")
+	orphanGrace := getEnvDuration("ORPHAN_GRACE", 24*time.Hour)
+	orphanRetention := getEnvDuration("ORPHAN_RETENTION", 30*24*time.Hour)
+	orphanInterval := getEnvDuration("ORPHAN_SWEEP_INTERVAL", time.Hour)
+	trashRetention := getEnvDuration("TRASH_RETENTION", store.DefaultTrashRetention)
+	eventLogDir := getEnv("EVENT_LOG_DIR", "")
+	signingKey := getEnv("GDPR_SIGNING_KEY", "")
+	receiptsFile := getEnv("ERASURE_RECEIPTS_FILE", "")
+	legacySunset := getEnvTime("LEGACY_ROUTES_SUNSET")
 
 	log.Printf("Starting server: instance=%s, port=%s", instanceID, port)
 
@@ -34,25 +64,148 @@ func main() {
 	}
 	log.Printf("Loaded %d assets from catalog", catalog.Global.Count())
 
-	// Initialize store - using MemoryStore for simplicity
-	storeImpl := store.NewMemoryStore()
-	log.Println("Using MemoryStore")
+	// Initialize store - an event log when EVENT_LOG_DIR is set, otherwise
+	// MemoryStore
+	var storeImpl store.Store
+	if eventLogDir != "" {
+		logStore, err := eventlog.OpenStore(eventLogDir, eventlog.Options{Sync: true})
+		if err != nil {
+			log.Fatalf("Failed to open event log: %v", err)
+		}
+		defer logStore.Close()
+		storeImpl = logStore
+		log.Printf("Using event log in %s (%d records)", eventLogDir, logStore.Log().NextOffset())
+	} else {
+		memStore := store.NewMemoryStore()
+		memStore.TrashRetention = trashRetention
+		storeImpl = memStore
+		log.Println("Using MemoryStore")
+	}
+
+	// Permanently delete removed favorites once their retention ends
+	if trash, ok := store.Find[store.Trash](storeImpl); ok {
+		go purgeTrashPeriodically(context.Background(), trash, time.Hour)
+	}
+
+	// Publish favorite changes to the in-process event bus
+	bus := events.NewBus(0)
+
+	// Deliver favorite changes to webhook subscribers
+	dispatcher := webhooks.NewDispatcher(webhooks.NewRegistry(), webhooks.Options{})
+	dispatcher.Start(context.Background())
+	bus.AddListener(dispatcher.Enqueue)
+
+	// Keep the recommendation engine in step with favorite changes
+	recommender := recommend.NewEngine()
+	if idx, ok := store.Find[store.PopularityIndex](storeImpl); ok {
+		if err := recommender.Load(context.Background(), idx); err != nil {
+			log.Fatalf("Failed to load recommendations: %v", err)
+		}
+	}
+	bus.AddListener(recommender.Observe)
+
+	publishingStore := events.NewStore(storeImpl, bus)
+
+	// Record every change made through the API for history and undo
+	historyStore := history.NewStore(publishingStore)
+
+	// Rebuild a user's dashboard only after their favorites change
+	dashboards := dashboard.NewCache(historyStore)
+	bus.AddListener(dashboards.Observe)
+
+	// Move favorites of replaced assets onto their replacements
+	if res, err := aliases.Migrate(context.Background(), historyStore, catalog.Global); errors.Is(err, aliases.ErrUnsupported) {
+		log.Println("Store does not support alias migration, skipping")
+	} else if err != nil {
+		log.Fatalf("Failed to migrate asset aliases: %v", err)
+	} else if res.Aliases > 0 {
+		log.Printf("Migrated favorites for %d asset aliases", res.Aliases)
+	}
+
+	// Archive and purge favorites whose asset has left the catalog
+	sweeper := orphans.NewSweeper(historyStore, orphanGrace, orphanRetention)
+	go sweeper.Run(context.Background(), orphanInterval)
+
+	// Sign user data exports and erasure receipts
+	var erasures *gdpr.Ledger
+	if signingKey != "" {
+		var err error
+		if erasures, err = gdpr.NewLedger([]byte(signingKey), receiptsFile); err != nil {
+			log.Fatalf("Failed to load erasure receipts: %v", err)
+		}
+	} else {
+		log.Println("GDPR_SIGNING_KEY not set, user data export and erasure disabled")
+	}
 
 	// Initialize API server
-	apiServer := &api.API{Store: storeImpl}
+	apiServer := &api.API{
+		Store:       historyStore,
+		Events:      bus,
+		History:     historyStore,
+		Dashboards:  dashboards,
+		Recommender: recommender,
+		Orphans:     sweeper,
+		Webhooks:    dispatcher,
+		SigningKey:  []byte(signingKey),
+		Erasures:    erasures,
+		AdminToken:  adminToken,
+		Sunset:      legacySunset,
+	}
+	if adminToken == "" {
+		log.Println("ADMIN_TOKEN not set, admin endpoints disabled")
+	}
 
 	r := mux.NewRouter()
+	v2 := &apiv2.API{API: apiServer}
+	v2.RegisterHandlers(r.PathPrefix("/v2").Subrouter())
 	apiServer.RegisterHandlers(r)
 
-	// Swagger UI
+	// Swagger UI, one document per API version
+	r.PathPrefix("/swagger/v2/").Handler(httpSwagger.Handler(
+		httpSwagger.InstanceName("v2"),
+		httpSwagger.URL("/swagger/v2/doc.json"),
+	))
 	r.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
+	// Serve the same API over gRPC for internal services
+	grpcServer := grpc.NewServer()
+	apiServer.RegisterGRPC(grpcServer)
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Could not listen for gRPC: %v", err)
+	}
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Could not start gRPC server: %v", err)
+		}
+	}()
+	log.Printf("gRPC server ready at :%s", grpcPort)
+
 	log.Printf("Server ready at :%s", port)
 	if err := http.ListenAndServe(":"+port, r); err != nil {
 		log.Fatalf("Could not start server: %v", err)
 	}
 }
 
+// purgeTrashPeriodically purges expired trash every interval until ctx is done
+func purgeTrashPeriodically(ctx context.Context, t store.Trash, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := t.PurgeTrash(ctx, now)
+			if err != nil {
+				log.Printf("Trash purge failed: %v", err)
+			} else if n > 0 {
+				log.Printf("Purged %d favorites from trash", n)
+			}
+		}
+	}
+}
+
 // getEnv gets an environment variable with a default fallback
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -60,3 +213,33 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvDuration parses a duration environment variable with a default fallback
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return d
+}
+
+// getEnvTime parses an RFC 3339 date or timestamp environment variable,
+// returning the zero time if it is unset
+func getEnvTime(key string) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t, err = time.Parse(time.DateOnly, value)
+	}
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return t
+}
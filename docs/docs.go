@@ -15,76 +15,124 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/assets": {
+        "/admin/catalog/aliases": {
             "get": {
-                "description": "Get a list of all assets in the catalog",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retired asset IDs and the assets that replace them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "assets"
+                    "admin"
                 ],
-                "summary": "List all available assets",
+                "summary": "List asset aliases",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/catalog.Alias"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "/healthz": {
+        "/admin/catalog/assets": {
             "get": {
-                "description": "Returns service status and version.",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Every asset with its type, aliases and deprecation, sorted by ID. Unlike /assets, deprecated assets are included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Health check",
+                "summary": "List catalog assets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/health.HealthResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/catalog.Entry"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/favorites": {
-            "get": {
-                "description": "Get all favorites for a specific user",
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Validates and adds a chart, insight or audience, writing the catalog back to its file",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "admin"
                 ],
-                "summary": "List user's favorites",
+                "summary": "Create catalog asset",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Asset",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.AssetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "$ref": "#/definitions/catalog.Entry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset ID already in use",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -94,9 +142,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Add an asset to user's favorites",
+            }
+        },
+        "/admin/catalog/assets/{assetID}": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Validates and replaces the asset, writing the catalog back to its file. Favorites of it show the change immediately.",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,48 +159,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "admin"
                 ],
-                "summary": "Add a favorite",
+                "summary": "Update catalog asset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Asset ID",
+                        "name": "assetID",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Asset ID and optional description",
+                        "description": "Asset",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api.AddFavoriteRequest"
+                            "$ref": "#/definitions/api.AssetRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/catalog.Entry"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid asset",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Asset not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Asset already favorited",
+                    "404": {
+                        "description": "Asset not found",
                         "schema": {
                             "type": "string"
                         }
@@ -157,23 +212,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/{id}/favorites/{assetID}": {
+            },
             "delete": {
-                "description": "Remove an asset from user's favorites",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes the asset, writing the catalog back to its file. Favorites of it become orphans, reported and swept by the /admin/orphans endpoints.",
                 "tags": [
-                    "favorites"
+                    "admin"
                 ],
-                "summary": "Remove a favorite",
+                "summary": "Delete catalog asset",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Asset ID",
@@ -184,7 +235,16 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
                         "schema": {
                             "type": "string"
                         }
@@ -196,56 +256,98 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update the description of an existing favorite",
-                "consumes": [
+            }
+        },
+        "/admin/catalog/assets/{assetID}/deprecate": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Hides the asset from asset listings. Existing favorites of it keep working.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "admin"
                 ],
-                "summary": "Edit favorite description",
+                "summary": "Deprecate catalog asset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Asset ID",
+                        "name": "assetID",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/catalog.Entry"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/catalog/assets/{assetID}/undeprecate": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Undeprecate catalog asset",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "Asset ID",
                         "name": "assetID",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New description",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/api.EditFavoriteRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/catalog.Entry"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Favorite not found",
+                        "description": "Asset not found",
                         "schema": {
                             "type": "string"
                         }
@@ -258,38 +360,2574 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api.AddFavoriteRequest": {
-            "type": "object",
-            "properties": {
-                "assetId": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                }
-            }
         },
-        "api.EditFavoriteRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
+        "/admin/catalog/migrate-aliases": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Rewrites every favorite of a replaced asset ID to its replacement, keeping descriptions and CreatedAt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Migrate favorites to replacement assets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/aliases.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "health.HealthResponse": {
-            "type": "object",
-            "properties": {
-                "status": {
-                    "type": "string"
-                },
-                "version": {
-                    "type": "string"
-                }
-            }
+        "/admin/erasures": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Every erasure receipt, oldest first, and whether the receipt chain verifies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List erasure receipts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErasureLedger"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Erasure not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orphans": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Per missing asset: how many users still favorite it and when it will be archived",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Orphaned favorites report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/orphans.Report"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orphans/archive": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Archived orphaned favorites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/orphans.ArchivedFavorite"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/orphans/sweep": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Run orphan sweep",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/orphans.SweepResult"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Subscription"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Register a URL to receive signed favorite change events",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List dead-lettered deliveries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Delivery"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/dead-letters/{deliveryID}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Redeliver a dead-lettered delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{subID}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.Subscription"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{subID}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Most recent delivery attempts first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "subID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only failed attempts",
+                        "name": "failed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum entries to return",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhooks.Attempt"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets": {
+            "get": {
+                "description": "Get a list of all assets in the catalog. When the store maintains a\npopularity index, each asset includes a favoriteCount field. With forUser,\neach asset also includes isFavorite and, when favorited, the user's description.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "List all available assets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Mark the assets this user has favorited",
+                        "name": "forUser",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/popular": {
+            "get": {
+                "description": "Top-N assets by number of users who favorited them, optionally for one asset type",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Most favorited assets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of assets (1-100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "chart",
+                            "insight",
+                            "audience"
+                        ],
+                        "type": "string",
+                        "description": "Asset type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.PopularAsset"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/assets/trending": {
+            "get": {
+                "description": "Assets ranked by time-decayed favorite counts: each favorite created within the window\ncontributes 0.5^(age/halfLife) to its asset's score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "assets"
+                ],
+                "summary": "Trending assets",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of assets (1-100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "chart",
+                            "insight",
+                            "audience"
+                        ],
+                        "type": "string",
+                        "description": "Asset type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "How far back to look, as a Go duration (default 168h)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Decay half-life, as a Go duration (default 24h)",
+                        "name": "halfLife",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.PopularAsset"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Returns service status and version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Deletes the user's favorites, trash, history, recent events, archived favorites and\nundelivered webhooks, and records a receipt in the erasure ledger. The receipt identifies\nthe user only by a keyed hash and is chained to the previous receipt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Erase a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gdpr.Receipt"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Erasure not enabled or not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/dashboard": {
+            "get": {
+                "description": "The user's favorites grouped into charts, insights and audiences, each with its total\ncount and its most recently favorited items as tiles: name, a one-line headline and\nthe asset description truncated for display.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "User dashboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tiles per group (1-50, default 6)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dashboard.Dashboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/data-export": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Zip archive with one JSON file per subsystem (favorites, tags, trash, history, recent events,\narchived favorites, undelivered webhooks), a manifest of their SHA-256 digests and\nmanifest.sig, an HMAC-SHA256 of the manifest under the server's signing key.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export all data held about a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signed zip archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Signing key not configured",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites": {
+            "get": {
+                "description": "Get all favorites for a specific user. Favorites whose asset has left the catalog\nare omitted unless includeUnavailable is set, in which case they carry status \"unavailable\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "List user's favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include favorites whose asset is no longer in the catalog",
+                        "name": "includeUnavailable",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add an asset to user's favorites",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Add a favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Asset ID and optional description",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.AddFavoriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset already favorited",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/events": {
+            "get": {
+                "description": "Server-Sent Events feed of favorite.added, favorite.removed and favorite.description_edited events.\nEach event id is the per-user sequence number; reconnect with Last-Event-ID to resume.\nA \"reset\" event means some events were lost and the client should re-fetch its favorites.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Stream favorite changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this sequence number",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/events.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Event stream not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/export": {
+            "get": {
+                "description": "Streams the user's favorites with asset type, name, the user's description, createdAt and\ntype-specific columns (chartType, metric/value, segment/size). The file can be imported again.\nCSV cells starting with =, +, -, @ or ' are prefixed with ' so that spreadsheets do not run them as formulas.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Export user's favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/export.Row"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/history": {
+            "get": {
+                "description": "Every change to the user's favorites, most recent first. Pass the returned\nnextBefore as before to fetch the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Favorites change history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries per page (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only entries with a lower seq",
+                        "name": "before",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/history.Page"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "History not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/import": {
+            "post": {
+                "description": "Accepts a file in the export format, either as the raw body or as the \"file\" field of a\nmultipart form. The format comes from the format parameter, else the Content-Type or\nfile extension. Asset IDs are validated against the catalog. Nothing is written on dry\nruns, malformed files or, with policy=fail, conflicts (reported with 409).\nA favorite added while the import runs stops a policy=fail import at that row with 409; the report shows which rows were written.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Import favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "fail"
+                        ],
+                        "type": "string",
+                        "description": "What to do with assets already favorited (default skip)",
+                        "name": "policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report what would change without writing",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/export.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflicts with policy=fail",
+                        "schema": {
+                            "$ref": "#/definitions/export.Report"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/trash": {
+            "get": {
+                "description": "Favorites removed by the user that can still be restored, most recently removed first.\nEach item's expiresAt is when it will be permanently purged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "List removed favorites",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TrashedFavorite"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/trash/{assetID}/restore": {
+            "post": {
+                "description": "Restores the favorite with its original description, creation time and position",
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Restore a removed favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not in trash",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Asset already favorited",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Not supported by store",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/undo": {
+            "post": {
+                "description": "Reverts the most recent change not already undone. Calling it again undoes the change before that.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Undo the last favorites change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The entry recording the revert",
+                        "schema": {
+                            "$ref": "#/definitions/history.Entry"
+                        }
+                    },
+                    "404": {
+                        "description": "Nothing to undo",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Favorite changed since",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "History not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/ws": {
+            "get": {
+                "description": "Upgrades to a WebSocket. Clients send api.SyncCommand messages (add, remove, edit)\nand receive api.SyncMessage acks, plus \"event\" messages for changes made by other sessions.\nEvents buffered after lastEventId (default 0) are pushed first.",
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Favorites sync over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sequence number of the last event the client has seen",
+                        "name": "lastEventId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/api.SyncMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid lastEventId",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/favorites/{assetID}": {
+            "get": {
+                "description": "Get one favorite with its asset. HEAD returns the same status without a body, as a\ncheap check of whether the asset is favorited. A favorite whose asset has left the\ncatalog is returned with status \"unavailable\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Get a favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoriteWithAsset"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Idempotent form of add: favorites the asset with the given description, or sets the\ndescription if it is already favorited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Add or update a favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Description",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.PutFavoriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Asset not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an asset from user's favorites. Stores with a trash keep it restorable for a retention period.\nRemoving an asset that is not favorited succeeds unless strict is set.",
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Remove a favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return 404 if the asset is not favorited",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite not found (strict only)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Get one favorite with its asset. HEAD returns the same status without a body, as a\ncheap check of whether the asset is favorited. A favorite whose asset has left the\ncatalog is returned with status \"unavailable\".",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Get a favorite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FavoriteWithAsset"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the description of an existing favorite",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Edit favorite description",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Asset ID",
+                        "name": "assetID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New description",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.EditFavoriteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "504": {
+                        "description": "Request timed out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/recommendations": {
+            "get": {
+                "description": "Catalog assets the user has not favorited, ranked by similarity to the assets\nthey have (\"users who favorited this also favorited\"). Users without favorites get an empty list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Recommended assets for a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of assets (1-100, default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "chart",
+                            "insight",
+                            "audience"
+                        ],
+                        "type": "string",
+                        "description": "Asset type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "jaccard",
+                            "cosine"
+                        ],
+                        "type": "string",
+                        "description": "Similarity metric (default jaccard)",
+                        "name": "metric",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.Recommendation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "501": {
+                        "description": "Recommendations not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "aliases.Result": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "Aliases processed",
+                    "type": "integer"
+                },
+                "merged": {
+                    "description": "Favorites folded into an existing favorite of the new asset",
+                    "type": "integer"
+                },
+                "migrated": {
+                    "description": "Favorites renamed to the new asset ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "api.AddFavoriteRequest": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.AssetRequest": {
+            "type": "object",
+            "properties": {
+                "chartType": {
+                    "description": "Charts",
+                    "type": "string"
+                },
+                "dataSource": {
+                    "description": "Charts",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Create only; generated when empty",
+                    "type": "string"
+                },
+                "metric": {
+                    "description": "Insights",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "segment": {
+                    "description": "Audiences",
+                    "type": "string"
+                },
+                "size": {
+                    "description": "Audiences",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "chart, insight or audience; defaults to the current type on update",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Insights",
+                    "type": "string"
+                }
+            }
+        },
+        "api.CreateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "eventTypes": {
+                    "description": "Empty subscribes to all event types",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/events.Type"
+                    }
+                },
+                "secret": {
+                    "description": "Used to sign deliveries; not returned",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.EditFavoriteRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ErasureLedger": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gdpr.Receipt"
+                    }
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.PopularAsset": {
+            "type": "object",
+            "properties": {
+                "asset": {},
+                "favoriteCount": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "Time-decayed score (trending only)",
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.PutFavoriteRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.Recommendation": {
+            "type": "object",
+            "properties": {
+                "asset": {},
+                "score": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.SyncMessage": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/events.Event"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastSeq": {
+                    "description": "Latest sequence number, for \"reset\"",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "HTTP-equivalent status of the command",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "catalog.Alias": {
+            "type": "object",
+            "properties": {
+                "newId": {
+                    "type": "string"
+                },
+                "oldId": {
+                    "type": "string"
+                }
+            }
+        },
+        "catalog.Entry": {
+            "type": "object",
+            "properties": {
+                "asset": {},
+                "deprecated": {
+                    "type": "boolean"
+                },
+                "replaces": {
+                    "description": "Retired IDs that resolve to this asset",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dashboard.Dashboard": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "description": "Charts, insights, audiences, always in that order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dashboard.Group"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dashboard.Group": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "All favorites of this type, not just Items",
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dashboard.Tile"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dashboard.Tile": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "headline": {
+                    "description": "e.g. \"bar chart\", \"Engagement: 40%\", \"18-24 (12000)\"",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "Asset description, truncated to SummaryLength",
+                    "type": "string"
+                }
+            }
+        },
+        "events.Event": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "New description for added/edited events",
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "Per-user sequence number, starting at 1",
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/events.Type"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "events.Type": {
+            "type": "string",
+            "enum": [
+                "favorite.added",
+                "favorite.removed",
+                "favorite.description_edited"
+            ],
+            "x-enum-varnames": [
+                "FavoriteAdded",
+                "FavoriteRemoved",
+                "DescriptionEdited"
+            ]
+        },
+        "export.Action": {
+            "type": "string",
+            "enum": [
+                "add",
+                "overwrite",
+                "skip",
+                "conflict",
+                "invalid"
+            ],
+            "x-enum-comments": {
+                "ActionConflict": "Conflict under PolicyFail",
+                "ActionInvalid": "Asset not in the catalog or row malformed",
+                "ActionSkip": "Conflict left alone, or description unchanged"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "Conflict left alone, or description unchanged",
+                "Conflict under PolicyFail",
+                "Asset not in the catalog or row malformed"
+            ],
+            "x-enum-varnames": [
+                "ActionAdd",
+                "ActionOverwrite",
+                "ActionSkip",
+                "ActionConflict",
+                "ActionInvalid"
+            ]
+        },
+        "export.Policy": {
+            "type": "string",
+            "enum": [
+                "skip",
+                "overwrite",
+                "fail"
+            ],
+            "x-enum-comments": {
+                "PolicyFail": "Abort the import without writing",
+                "PolicyOverwrite": "Replace its description",
+                "PolicySkip": "Leave the existing favorite alone"
+            },
+            "x-enum-descriptions": [
+                "Leave the existing favorite alone",
+                "Replace its description",
+                "Abort the import without writing"
+            ],
+            "x-enum-varnames": [
+                "PolicySkip",
+                "PolicyOverwrite",
+                "PolicyFail"
+            ]
+        },
+        "export.Report": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "overwritten": {
+                    "type": "integer"
+                },
+                "policy": {
+                    "$ref": "#/definitions/export.Policy"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/export.RowResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "export.Row": {
+            "type": "object",
+            "properties": {
+                "assetId": {
+                    "type": "string"
+                },
+                "chartType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "description": "The user's description, not the asset's",
+                    "type": "string"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "segment": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "export.RowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/export.Action"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "description": "1-based position in the file",
+                    "type": "integer"
+                }
+            }
+        },
+        "gdpr.Receipt": {
+            "type": "object",
+            "properties": {
+                "erased": {
+                    "description": "Items erased per kind",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "erasedAt": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "history.Action": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "edited",
+                "restored",
+                "migrated"
+            ],
+            "x-enum-comments": {
+                "Migrated": "Moved from a retired asset ID to its replacement",
+                "Restored": "Brought back from the trash"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "Brought back from the trash",
+                "Moved from a retired asset ID to its replacement"
+            ],
+            "x-enum-varnames": [
+                "Added",
+                "Removed",
+                "Edited",
+                "Restored",
+                "Migrated"
+            ]
+        },
+        "history.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/history.Action"
+                },
+                "actor": {
+                    "description": "Who made the change, see WithActor",
+                    "type": "string"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "fromAssetId": {
+                    "description": "Retired asset ID, for Migrated",
+                    "type": "string"
+                },
+                "newDescription": {
+                    "type": "string"
+                },
+                "oldDescription": {
+                    "type": "string"
+                },
+                "seq": {
+                    "description": "Per-user sequence number, starting at 1",
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "undoes": {
+                    "description": "Seq of the entry this one reverted",
+                    "type": "integer"
+                },
+                "undone": {
+                    "description": "Reverted by a later entry",
+                    "type": "boolean"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "history.Page": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/history.Entry"
+                    }
+                },
+                "nextBefore": {
+                    "description": "NextBefore is the cursor for the next (older) page, 0 if there is none.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FavoriteWithAsset": {
+            "type": "object",
+            "properties": {
+                "asset": {
+                    "description": "Full asset from catalog"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "StatusUnavailable when the asset left the catalog",
+                    "type": "string"
+                }
+            }
+        },
+        "models.TrashedFavorite": {
+            "type": "object",
+            "properties": {
+                "asset": {},
+                "assetId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "description": "When the purge job deletes it",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "orphans.ArchivedFavorite": {
+            "type": "object",
+            "properties": {
+                "archivedAt": {
+                    "type": "string"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "purgeAfter": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "orphans.Report": {
+            "type": "object",
+            "properties": {
+                "archiveAfter": {
+                    "type": "string"
+                },
+                "assetId": {
+                    "type": "string"
+                },
+                "favoriteCount": {
+                    "type": "integer"
+                },
+                "firstSeen": {
+                    "description": "Zero until a sweep has seen it",
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "orphans.SweepResult": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "description": "Favorites moved to the archive",
+                    "type": "integer"
+                },
+                "detected": {
+                    "description": "Newly seen orphaned assets",
+                    "type": "integer"
+                },
+                "purged": {
+                    "description": "Archive entries deleted",
+                    "type": "integer"
+                }
+            }
+        },
+        "webhooks.Attempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "deliveryId": {
+                    "type": "string"
+                },
+                "durationNs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "$ref": "#/definitions/events.Type"
+                },
+                "statusCode": {
+                    "type": "integer"
+                },
+                "subscriptionId": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/events.Event"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastStatus": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/webhooks.DeliveryState"
+                },
+                "subscriptionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.DeliveryState": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "StatePending",
+                "StateSucceeded",
+                "StateDead"
+            ]
+        },
+        "webhooks.Subscription": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "eventTypes": {
+                    "description": "Empty means all event types",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/events.Type"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin bearer token, e.g. \"Bearer \u003cADMIN_TOKEN\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -298,7 +2936,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "0.1",
 	Host:             "localhost:8080",
-	BasePath:         "/",
+	BasePath:         "/v1",
 	Schemes:          []string{},
 	Title:            "Favorites API",
 	Description:      "API for managing user's favorite assets",
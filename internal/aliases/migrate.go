@@ -0,0 +1,57 @@
+// Package aliases rewrites favorites of replaced assets to their
+// replacements, following the aliases registered in the catalog.
+package aliases
+
+import (
+	"context"
+	"errors"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/store"
+)
+
+// ErrUnsupported is returned when the store cannot migrate favorites.
+var ErrUnsupported = errors.New("store does not support asset migration")
+
+// Actor is the history actor recorded for migrations not attributed to
+// anyone else.
+const Actor = "alias-migration"
+
+// Result summarises a migration run.
+type Result struct {
+	Aliases  int `json:"aliases"`  // Aliases processed
+	Migrated int `json:"migrated"` // Favorites renamed to the new asset ID
+	Merged   int `json:"merged"`   // Favorites folded into an existing favorite of the new asset
+}
+
+// Migrate rewrites favorites for every alias in c through s, which
+// should be the outermost store so that its decorators (events, history)
+// publish and record each migrated favorite under their own locks.
+// Running it again is harmless: already-migrated aliases have no favorites.
+func Migrate(ctx context.Context, s store.Store, c *catalog.Catalog) (Result, error) {
+	var res Result
+	migrator, ok := store.Find[store.AssetMigrator](s)
+	if !ok {
+		return res, ErrUnsupported
+	}
+	if history.ActorFrom(ctx) == "" {
+		ctx = history.WithActor(ctx, Actor)
+	}
+
+	for _, alias := range c.Aliases() {
+		res.Aliases++
+		moved, err := migrator.MigrateAsset(ctx, alias.OldID, alias.NewID)
+		if err != nil {
+			return res, err
+		}
+		for _, m := range moved {
+			if m.Merged {
+				res.Merged++
+			} else {
+				res.Migrated++
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package aliases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/history"
+	"my-solution/internal/store"
+)
+
+const seed = `{
+  "charts": [
+    {"ID": "chart-v2", "Name": "Revenue", "ChartType": "bar", "Replaces": ["chart-v1"]}
+  ],
+  "insights": [
+    {"ID": "insight-1", "Name": "Growth", "Metric": "growth", "Value": "15%"}
+  ],
+  "audiences": []
+}`
+
+func loadSeed(t *testing.T) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "seed.json")
+	if err := os.WriteFile(path, []byte(seed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	catalog.Initialize()
+	if err := catalog.Global.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCatalogResolvesReplacedIDs(t *testing.T) {
+	loadSeed(t)
+
+	asset, ok := catalog.Global.Get("chart-v1")
+	if !ok || asset.GetID() != "chart-v2" {
+		t.Fatalf("expected chart-v1 to resolve to chart-v2, got %v %v", asset, ok)
+	}
+	if id, ok := catalog.Global.Resolve("chart-v1"); !ok || id != "chart-v2" {
+		t.Errorf("Resolve: %q %v", id, ok)
+	}
+	if catalog.Global.Count() != 2 || len(catalog.Global.List()) != 2 {
+		t.Error("aliases must not be counted or listed as assets")
+	}
+	if err := catalog.Global.AddAlias("x", "nope"); err != catalog.ErrAliasTargetNotFound {
+		t.Errorf("expected ErrAliasTargetNotFound, got %v", err)
+	}
+	if err := catalog.Global.AddAlias("insight-1", "chart-v2"); err != catalog.ErrAliasConflict {
+		t.Errorf("expected ErrAliasConflict, got %v", err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	loadSeed(t)
+
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "insight-1", "")
+	s.AddFavorite(ctx, "u1", "chart-v1", "old note")
+	s.AddFavorite(ctx, "u2", "chart-v2", "")
+	s.AddFavorite(ctx, "u2", "chart-v1", "u2 note")
+	s.AddFavorite(ctx, "u3", "chart-v1", "trashed")
+	s.RemoveFavorite(ctx, "u3", "chart-v1")
+	before, _ := s.ListFavorites(ctx, "u1")
+
+	bus := events.NewBus(0)
+	hs := history.NewStore(events.NewStore(s, bus))
+	res, err := Migrate(ctx, hs, catalog.Global)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != (Result{Aliases: 1, Migrated: 1, Merged: 1}) {
+		t.Errorf("unexpected result %+v", res)
+	}
+
+	// u1's favorite is renamed in place with description and CreatedAt kept.
+	after, _ := s.ListFavorites(ctx, "u1")
+	if len(after) != 2 || after[1].AssetID != "chart-v2" || after[1].Description != "old note" ||
+		!after[1].CreatedAt.Equal(before[1].CreatedAt) {
+		t.Errorf("unexpected u1 favorites %+v", after)
+	}
+
+	// u2's duplicate is merged into the existing favorite.
+	after, _ = s.ListFavorites(ctx, "u2")
+	if len(after) != 1 || after[0].AssetID != "chart-v2" || after[0].Description != "u2 note" {
+		t.Errorf("unexpected u2 favorites %+v", after)
+	}
+
+	counts, _ := s.FavoriteCounts(ctx)
+	if counts["chart-v2"] != 2 || counts["chart-v1"] != 0 {
+		t.Errorf("popularity index not migrated: %v", counts)
+	}
+	if bus.LastSeq("u1") != 2 || bus.LastSeq("u2") != 1 {
+		t.Errorf("unexpected events published: u1=%d u2=%d", bus.LastSeq("u1"), bus.LastSeq("u2"))
+	}
+	page := hs.History("u1", 0, 0)
+	if len(page.Entries) != 1 || page.Entries[0].Action != history.Migrated || page.Entries[0].FromAssetID != "chart-v1" ||
+		page.Entries[0].AssetID != "chart-v2" || page.Entries[0].Actor != Actor {
+		t.Errorf("migration not recorded in history: %+v", page.Entries)
+	}
+	if _, err := hs.Undo(ctx, "u1"); err != history.ErrNothingToUndo {
+		t.Errorf("expected migrations not to be undoable, got %v", err)
+	}
+
+	// Trashed favorites are migrated too, so restoring one brings back the
+	// replacement.
+	if err := s.RestoreFavorite(ctx, "u3", "chart-v2"); err != nil {
+		t.Errorf("restore migrated trash entry: %v", err)
+	}
+	if trash, _ := s.ListTrash(ctx, "u3"); len(trash) != 0 {
+		t.Errorf("unexpected trash %+v", trash)
+	}
+
+	// A second run finds nothing to do.
+	if res, _ := Migrate(ctx, hs, catalog.Global); res.Migrated+res.Merged != 0 {
+		t.Errorf("second run migrated again: %+v", res)
+	}
+}
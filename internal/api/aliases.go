@@ -0,0 +1,51 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"my-solution/internal/aliases"
+	"my-solution/internal/catalog"
+)
+
+// listAliasesHandler lists asset IDs that have been replaced.
+// @Summary List asset aliases
+// @Description Retired asset IDs and the assets that replace them
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} catalog.Alias
+// @Failure 401 {string} string "Unauthorized"
+// @Router /admin/catalog/aliases [get]
+func (api *API) listAliasesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(catalog.Global.Aliases())
+}
+
+// migrateAliasesHandler rewrites favorites of replaced assets.
+// @Summary Migrate favorites to replacement assets
+// @Description Rewrites every favorite of a replaced asset ID to its replacement, keeping descriptions and CreatedAt
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} aliases.Result
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 501 {string} string "Not supported by store"
+// @Router /admin/catalog/migrate-aliases [post]
+func (api *API) migrateAliasesHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := aliases.Migrate(r.Context(), api.Store, catalog.Global)
+	if err != nil {
+		if errors.Is(err, aliases.ErrUnsupported) {
+			http.Error(w, err.Error(), http.StatusNotImplemented)
+			return
+		}
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "migration failed", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
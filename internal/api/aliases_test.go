@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-solution/internal/aliases"
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMigrateAliasesEndpoint(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("new-chart", models.Chart{AssetBase: models.AssetBase{ID: "new-chart", Name: "New"}, ChartType: "bar"})
+
+	s := store.NewMemoryStore()
+	s.AddFavorite(context.Background(), "u1", "old-chart", "note")
+	api := &API{Store: s, AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	if err := catalog.Global.AddAlias("old-chart", "new-chart"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Before migration the favorite is joined through the alias.
+	var favs []map[string]interface{}
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 || favs[0]["assetId"] != "old-chart" {
+		t.Fatalf("unexpected favorites %v", favs)
+	}
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("POST", "/admin/catalog/migrate-aliases", nil))
+	var result aliases.Result
+	json.NewDecoder(res.Body).Decode(&result)
+	if res.Code != http.StatusOK || result.Migrated != 1 {
+		t.Fatalf("unexpected migration result %d %+v", res.Code, result)
+	}
+
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 || favs[0]["assetId"] != "new-chart" || favs[0]["description"] != "note" {
+		t.Errorf("favorite not migrated: %v", favs)
+	}
+}
+
+func TestAddFavoriteByRetiredIDStoresReplacement(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("new-chart", models.Chart{AssetBase: models.AssetBase{ID: "new-chart", Name: "New"}, ChartType: "bar"})
+	if err := catalog.Global.AddAlias("old-chart", "new-chart"); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &API{Store: store.NewMemoryStore()}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	res := executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "old-chart"})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("expected 201 adding by old ID, got %d", res.Code)
+	}
+	res = executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "new-chart"})
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected 409 adding by new ID, got %d", res.Code)
+	}
+	res = executeRequest(r, "PUT", "/users/u1/favorites/old-chart", map[string]string{"description": "note"})
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200 upserting by old ID, got %d", res.Code)
+	}
+
+	var favs []map[string]interface{}
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 || favs[0]["assetId"] != "new-chart" || favs[0]["description"] != "note" {
+		t.Errorf("expected one favorite stored under new-chart, got %v", favs)
+	}
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+// AssetRequest defines the body for creating or updating a catalog asset.
+// Only the fields of its type are used.
+type AssetRequest struct {
+	Type        string `json:"type"` // chart, insight or audience; defaults to the current type on update
+	ID          string `json:"id"`   // Create only; generated when empty
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ChartType   string `json:"chartType"`  // Charts
+	DataSource  string `json:"dataSource"` // Charts
+	Metric      string `json:"metric"`     // Insights
+	Value       string `json:"value"`      // Insights
+	Segment     string `json:"segment"`    // Audiences
+	Size        int    `json:"size"`       // Audiences
+}
+
+// asset builds the asset the request describes under id.
+func (req AssetRequest) asset(id string) (models.Asset, bool) {
+	base := models.AssetBase{ID: id, Name: req.Name, Description: req.Description}
+	switch req.Type {
+	case models.TypeChart:
+		return models.Chart{AssetBase: base, ChartType: req.ChartType, DataSource: req.DataSource}, true
+	case models.TypeInsight:
+		return models.Insight{AssetBase: base, Metric: req.Metric, Value: req.Value}, true
+	case models.TypeAudience:
+		return models.Audience{AssetBase: base, Segment: req.Segment, Size: req.Size}, true
+	}
+	return nil, false
+}
+
+// writeCatalogError maps catalog errors to status codes.
+func writeCatalogError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, catalog.ErrInvalidAsset):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, catalog.ErrAssetNotFound):
+		http.Error(w, "asset not found", http.StatusNotFound)
+	case errors.Is(err, catalog.ErrAssetExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "failed to save catalog", http.StatusInternalServerError)
+	}
+}
+
+// catalogChanged drops cached dashboards, which hold asset names and
+// descriptions. Favorite joins read the catalog directly and need nothing.
+func (api *API) catalogChanged() {
+	if api.Dashboards != nil {
+		api.Dashboards.InvalidateAll()
+	}
+}
+
+// writeCatalogEntry writes the catalog entry for id.
+func writeCatalogEntry(w http.ResponseWriter, id string, status int) {
+	entry, _ := catalog.Global.Entry(id)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// listCatalogAssetsHandler lists every catalog asset.
+// @Summary List catalog assets
+// @Description Every asset with its type, aliases and deprecation, sorted by ID. Unlike /assets, deprecated assets are included.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} catalog.Entry
+// @Failure 401 {string} string "Unauthorized"
+// @Router /admin/catalog/assets [get]
+func (api *API) listCatalogAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(catalog.Global.Entries())
+}
+
+// createCatalogAssetHandler adds an asset to the catalog.
+// @Summary Create catalog asset
+// @Description Validates and adds a chart, insight or audience, writing the catalog back to its file
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body api.AssetRequest true "Asset"
+// @Success 201 {object} catalog.Entry
+// @Failure 400 {string} string "Invalid asset"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 409 {string} string "Asset ID already in use"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/catalog/assets [post]
+func (api *API) createCatalogAssetHandler(w http.ResponseWriter, r *http.Request) {
+	var req AssetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	asset, ok := req.asset(req.ID)
+	if !ok {
+		http.Error(w, "invalid asset type", http.StatusBadRequest)
+		return
+	}
+
+	asset, err := catalog.Global.Create(asset)
+	if err != nil {
+		writeCatalogError(w, err)
+		return
+	}
+	api.catalogChanged()
+	writeCatalogEntry(w, asset.GetID(), http.StatusCreated)
+}
+
+// updateCatalogAssetHandler replaces a catalog asset.
+// @Summary Update catalog asset
+// @Description Validates and replaces the asset, writing the catalog back to its file. Favorites of it show the change immediately.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param assetID path string true "Asset ID"
+// @Param request body api.AssetRequest true "Asset"
+// @Success 200 {object} catalog.Entry
+// @Failure 400 {string} string "Invalid asset"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Asset not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/catalog/assets/{assetID} [put]
+func (api *API) updateCatalogAssetHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["assetID"]
+
+	var req AssetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.ID != "" && req.ID != id {
+		http.Error(w, "asset ID does not match path", http.StatusBadRequest)
+		return
+	}
+	if req.Type == "" {
+		entry, ok := catalog.Global.Entry(id)
+		if !ok {
+			http.Error(w, "asset not found", http.StatusNotFound)
+			return
+		}
+		req.Type = entry.Type
+	}
+	asset, ok := req.asset(id)
+	if !ok {
+		http.Error(w, "invalid asset type", http.StatusBadRequest)
+		return
+	}
+
+	if err := catalog.Global.Update(asset); err != nil {
+		writeCatalogError(w, err)
+		return
+	}
+	api.catalogChanged()
+	writeCatalogEntry(w, id, http.StatusOK)
+}
+
+// deprecateCatalogAssetHandler marks a catalog asset deprecated.
+// @Summary Deprecate catalog asset
+// @Description Hides the asset from asset listings. Existing favorites of it keep working.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param assetID path string true "Asset ID"
+// @Success 200 {object} catalog.Entry
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Asset not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/catalog/assets/{assetID}/deprecate [post]
+func (api *API) deprecateCatalogAssetHandler(w http.ResponseWriter, r *http.Request) {
+	api.setDeprecated(w, mux.Vars(r)["assetID"], true)
+}
+
+// undeprecateCatalogAssetHandler lists a deprecated catalog asset again.
+// @Summary Undeprecate catalog asset
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param assetID path string true "Asset ID"
+// @Success 200 {object} catalog.Entry
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Asset not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/catalog/assets/{assetID}/undeprecate [post]
+func (api *API) undeprecateCatalogAssetHandler(w http.ResponseWriter, r *http.Request) {
+	api.setDeprecated(w, mux.Vars(r)["assetID"], false)
+}
+
+func (api *API) setDeprecated(w http.ResponseWriter, id string, deprecated bool) {
+	if err := catalog.Global.Deprecate(id, deprecated); err != nil {
+		writeCatalogError(w, err)
+		return
+	}
+	api.catalogChanged()
+	writeCatalogEntry(w, id, http.StatusOK)
+}
+
+// deleteCatalogAssetHandler removes a catalog asset.
+// @Summary Delete catalog asset
+// @Description Removes the asset, writing the catalog back to its file. Favorites of it become orphans, reported and swept by the /admin/orphans endpoints.
+// @Tags admin
+// @Security AdminToken
+// @Param assetID path string true "Asset ID"
+// @Success 204 "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Asset not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /admin/catalog/assets/{assetID} [delete]
+func (api *API) deleteCatalogAssetHandler(w http.ResponseWriter, r *http.Request) {
+	if err := catalog.Global.Delete(mux.Vars(r)["assetID"]); err != nil {
+		writeCatalogError(w, err)
+		return
+	}
+	api.catalogChanged()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/dashboard"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestCatalogAssetEndpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	catalog.Initialize()
+	if err := catalog.Global.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	s := store.NewMemoryStore()
+	api := &API{Store: s, Dashboards: dashboard.NewCache(s), AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+	admin := func(method, path string, payload interface{}) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, adminRequest(method, path, payload))
+		return res
+	}
+
+	if res := executeRequest(r, "POST", "/admin/catalog/assets", AssetRequest{Type: "chart", Name: "Revenue", ChartType: "bar"}); res.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without the admin token, got %d", res.Code)
+	}
+	if res := admin("POST", "/admin/catalog/assets", AssetRequest{Type: "chart", Name: "Revenue"}); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a chart without a chart type, got %d", res.Code)
+	}
+	if res := admin("POST", "/admin/catalog/assets", AssetRequest{Type: "table", Name: "Revenue"}); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown type, got %d", res.Code)
+	}
+
+	res := admin("POST", "/admin/catalog/assets", AssetRequest{Type: "chart", ID: "c1", Name: "Revenue", ChartType: "bar"})
+	if res.Code != http.StatusCreated {
+		t.Fatalf("create: %d %s", res.Code, res.Body)
+	}
+	if res := admin("POST", "/admin/catalog/assets", AssetRequest{Type: "chart", ID: "c1", Name: "Revenue", ChartType: "bar"}); res.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a taken ID, got %d", res.Code)
+	}
+
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "c1"})
+	executeRequest(r, "GET", "/users/u1/dashboard", nil) // Cache the dashboard
+
+	// Updates show up in favorites and dashboards right away.
+	res = admin("PUT", "/admin/catalog/assets/c1", AssetRequest{Name: "Revenue (EUR)", ChartType: "line"})
+	if res.Code != http.StatusOK {
+		t.Fatalf("update: %d %s", res.Code, res.Body)
+	}
+	var favs []struct {
+		Asset struct{ Name string }
+	}
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 || favs[0].Asset.Name != "Revenue (EUR)" {
+		t.Errorf("expected the updated asset in favorites, got %+v", favs)
+	}
+	d, _ := api.Dashboards.Get(context.Background(), "u1")
+	if d.Groups[0].Items[0].Name != "Revenue (EUR)" {
+		t.Errorf("expected the dashboard to be rebuilt, got %+v", d.Groups[0].Items)
+	}
+	if res := admin("PUT", "/admin/catalog/assets/c2", AssetRequest{Name: "X", ChartType: "bar"}); res.Code != http.StatusNotFound {
+		t.Errorf("expected 404 updating a missing asset, got %d", res.Code)
+	}
+
+	// Deprecated assets leave /assets but favorites of them keep working.
+	if res := admin("POST", "/admin/catalog/assets/c1/deprecate", nil); res.Code != http.StatusOK {
+		t.Fatalf("deprecate: %d %s", res.Code, res.Body)
+	}
+	var assets []map[string]interface{}
+	json.NewDecoder(executeRequest(r, "GET", "/assets", nil).Body).Decode(&assets)
+	if len(assets) != 0 {
+		t.Errorf("expected deprecated asset to be unlisted, got %v", assets)
+	}
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 {
+		t.Errorf("expected the favorite of a deprecated asset, got %+v", favs)
+	}
+	var entries []map[string]interface{}
+	json.NewDecoder(admin("GET", "/admin/catalog/assets", nil).Body).Decode(&entries)
+	if len(entries) != 1 || entries[0]["deprecated"] != true || entries[0]["type"] != "chart" {
+		t.Errorf("unexpected admin listing %v", entries)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved struct{ Charts []map[string]interface{} }
+	if err := json.Unmarshal(data, &saved); err != nil || len(saved.Charts) != 1 || saved.Charts[0]["ChartType"] != "line" || saved.Charts[0]["Deprecated"] != true {
+		t.Errorf("unexpected catalog file %s: %v", data, err)
+	}
+
+	if res := admin("DELETE", "/admin/catalog/assets/c1", nil); res.Code != http.StatusNoContent {
+		t.Fatalf("delete: %d %s", res.Code, res.Body)
+	}
+	if res := admin("DELETE", "/admin/catalog/assets/c1", nil); res.Code != http.StatusNotFound {
+		t.Errorf("expected 404 deleting twice, got %d", res.Code)
+	}
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 0 {
+		t.Errorf("expected the favorite of a deleted asset to be hidden, got %+v", favs)
+	}
+}
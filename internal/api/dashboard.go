@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"my-solution/internal/dashboard"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultDashboardLimit is the number of tiles per group on the dashboard.
+const defaultDashboardLimit = 6
+
+// dashboardHandler returns a user's favorites grouped for the frontpage.
+// @Summary User dashboard
+// @Description The user's favorites grouped into charts, insights and audiences, each with its total
+// @Description count and its most recently favorited items as tiles: name, a one-line headline and
+// @Description the asset description truncated for display.
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Param limit query int false "Tiles per group (1-50, default 6)"
+// @Success 200 {object} dashboard.Dashboard
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
+// @Router /users/{id}/dashboard [get]
+func (api *API) dashboardHandler(w http.ResponseWriter, r *http.Request) {
+	limit := defaultDashboardLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 50 {
+			http.Error(w, "limit must be between 1 and 50", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	userID := mux.Vars(r)["id"]
+	var (
+		d   dashboard.Dashboard
+		err error
+	)
+	if api.Dashboards != nil {
+		d, err = api.Dashboards.Get(r.Context(), userID)
+	} else {
+		d, err = dashboard.NewCache(api.Store).Get(r.Context(), userID)
+	}
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to build dashboard", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(d.Top(limit))
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/dashboard"
+	"my-solution/internal/models"
+)
+
+func TestDashboard(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("d1", models.Chart{AssetBase: models.AssetBase{ID: "d1", Name: "One"}, ChartType: "bar"})
+	catalog.Global.AddAsset("d2", models.Chart{AssetBase: models.AssetBase{ID: "d2", Name: "Two"}, ChartType: "line"})
+	catalog.Global.AddAsset("d3", models.Audience{AssetBase: models.AssetBase{ID: "d3", Name: "Three"}, Segment: "18-24", Size: 1200})
+	r, _ := setupRouter()
+
+	for _, id := range []string{"d1", "d2", "d3"} {
+		executeRequest(r, "POST", "/users/u1/favorites", AddFavoriteRequest{AssetID: id})
+	}
+
+	res := executeRequest(r, "GET", "/users/u1/dashboard?limit=1", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var d dashboard.Dashboard
+	json.NewDecoder(res.Body).Decode(&d)
+	if d.Total != 3 || len(d.Groups) != 3 {
+		t.Fatalf("unexpected dashboard %+v", d)
+	}
+	if g := d.Groups[0]; g.Count != 2 || len(g.Items) != 1 {
+		t.Errorf("expected 2 charts with 1 tile, got %+v", g)
+	}
+	if g := d.Groups[2]; g.Count != 1 || g.Items[0].Headline != "18-24 (1200)" {
+		t.Errorf("unexpected audiences %+v", g)
+	}
+
+	if res := executeRequest(r, "GET", "/users/u1/dashboard?limit=0", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for limit=0, got %d", res.Code)
+	}
+}
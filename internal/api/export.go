@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"time"
+
+	"my-solution/internal/export"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// exportFlushEvery is how many rows are written between flushes, so large
+// exports reach the client progressively.
+const exportFlushEvery = 100
+
+// unsafeFilename matches characters replaced in download filenames.
+var unsafeFilename = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportFavoritesHandler streams a user's favorites as a downloadable file.
+// @Summary Export user's favorites
+// @Description Streams the user's favorites with asset type, name, the user's description, createdAt and
+// @Description type-specific columns (chartType, metric/value, segment/size). The file can be imported again.
+// @Description CSV cells starting with =, +, -, @ or ' are prefixed with ' so that spreadsheets do not run them as formulas.
+// @Tags favorites
+// @Produce json
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param id path string true "User ID"
+// @Param format query string false "File format (default json)" Enums(csv, json, ndjson)
+// @Success 200 {array} export.Row
+// @Failure 400 {string} string "Bad request"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
+// @Router /users/{id}/favorites/export [get]
+func (api *API) exportFavoritesHandler(w http.ResponseWriter, r *http.Request) {
+	format := export.JSON
+	if v := r.URL.Query().Get("format"); v != "" {
+		var err error
+		if format, err = export.ParseFormat(v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID := mux.Vars(r)["id"]
+	filename := fmt.Sprintf("favorites-%s-%s.%s", unsafeFilename.ReplaceAllString(userID, "_"), time.Now().UTC().Format("20060102"), format)
+
+	// Rows are encoded straight to the response as the store reads them;
+	// the headers go out with the first row, so a store error before it is
+	// still reported with a status, and later failures just end the body.
+	flusher, _ := w.(http.Flusher)
+	var ew *export.Writer
+	begin := func() {
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		ew = export.NewWriter(w, format)
+	}
+	rows := 0
+	err := api.scanFavorites(r.Context(), userID, func(fav models.FavoriteWithAsset) error {
+		if ew == nil {
+			begin()
+		}
+		if err := ew.Write(export.FromFavorite(fav)); err != nil {
+			return errExportAborted
+		}
+		if rows++; flusher != nil && rows%exportFlushEvery == 0 {
+			if ew.Flush() != nil {
+				return errExportAborted
+			}
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if ew != nil {
+			return
+		}
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to list favorites", http.StatusInternalServerError)
+		return
+	}
+	if ew == nil {
+		begin()
+	}
+	ew.Close()
+}
+
+// errExportAborted stops a scan once the response can no longer be written.
+var errExportAborted = errors.New("export aborted")
+
+// scanFavorites calls fn with each of the user's favorites, as the store
+// reads them if it implements store.FavoriteScanner and from one
+// ListFavorites call otherwise.
+func (api *API) scanFavorites(ctx context.Context, userID string, fn func(models.FavoriteWithAsset) error) error {
+	if scanner, ok := store.Find[store.FavoriteScanner](api.Store); ok {
+		return scanner.ScanFavorites(ctx, userID, fn)
+	}
+	favorites, err := api.Store.ListFavorites(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, fav := range favorites {
+		if err := fn(fav); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/export"
+	"my-solution/internal/models"
+)
+
+func TestExportFavoritesHandler(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("exp-chart", models.Chart{AssetBase: models.AssetBase{ID: "exp-chart", Name: "Revenue"}, ChartType: "line"})
+	catalog.Global.AddAsset("exp-aud", models.Audience{AssetBase: models.AssetBase{ID: "exp-aud", Name: "Gen Z"}, Segment: "18-24", Size: 1200})
+	r, s := setupRouter()
+	s.AddFavorite(context.Background(), "u:1", "exp-chart", "mine")
+	s.AddFavorite(context.Background(), "u:1", "exp-aud", "")
+
+	res := executeRequest(r, "GET", "/users/u:1/favorites/export?format=csv", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	if ct := res.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if cd := res.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment; filename=favorites-u_1-") || !strings.HasSuffix(cd, ".csv") {
+		t.Errorf("unexpected content disposition %q", cd)
+	}
+	lines := strings.Split(strings.TrimSpace(res.Body.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "exp-chart,chart,Revenue,mine,") || !strings.HasSuffix(lines[2], ",18-24,1200") {
+		t.Errorf("unexpected csv\n%s", res.Body.String())
+	}
+
+	var rows []export.Row
+	res = executeRequest(r, "GET", "/users/u:1/favorites/export", nil)
+	if err := json.NewDecoder(res.Body).Decode(&rows); err != nil || len(rows) != 2 {
+		t.Errorf("default json export: %v, %+v", err, rows)
+	}
+
+	if res := executeRequest(r, "GET", "/users/u1/favorites/export?format=xlsx", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", res.Code)
+	}
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"my-solution/internal/events"
+	"my-solution/internal/gdpr"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/orphans"
+	"my-solution/internal/store"
+	"my-solution/internal/webhooks"
+
+	"github.com/gorilla/mux"
+)
+
+// userDataFiles gathers everything held about userID into archive files.
+func (api *API) userDataFiles(r *http.Request, userID string) ([]gdpr.File, error) {
+	ctx := r.Context()
+
+	var (
+		favorites []models.FavoriteWithAsset
+		err       error
+	)
+	if lister, ok := store.Find[store.UnavailableLister](api.Store); ok {
+		favorites, err = lister.ListFavoritesWithUnavailable(ctx, userID)
+	} else {
+		favorites, err = api.Store.ListFavorites(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string][]string)
+	for _, fav := range favorites {
+		for _, tag := range models.Tags(fav.Description) {
+			tags[tag] = append(tags[tag], fav.AssetID)
+		}
+	}
+
+	trash := make([]models.TrashedFavorite, 0)
+	if t, ok := store.Find[store.Trash](api.Store); ok {
+		if trash, err = t.ListTrash(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	historyEntries := make([]history.Entry, 0)
+	if api.History != nil {
+		historyEntries = api.History.History(userID, 0, 0).Entries
+	}
+
+	recent := make([]events.Event, 0)
+	if api.Events != nil {
+		recent = append(recent, api.Events.Recent(userID)...)
+	}
+
+	archived := make([]orphans.ArchivedFavorite, 0)
+	if api.Orphans != nil {
+		for _, a := range api.Orphans.Archived() {
+			if a.UserID == userID {
+				archived = append(archived, a)
+			}
+		}
+	}
+
+	deadLetters := make([]webhooks.Delivery, 0)
+	if api.Webhooks != nil {
+		for _, d := range api.Webhooks.DeadLetters() {
+			if d.Event.UserID == userID {
+				deadLetters = append(deadLetters, d)
+			}
+		}
+	}
+
+	return []gdpr.File{
+		{Name: "favorites.json", Data: favorites},
+		{Name: "tags.json", Data: tags},
+		{Name: "trash.json", Data: trash},
+		{Name: "history.json", Data: historyEntries},
+		{Name: "events.json", Data: recent},
+		{Name: "archived-favorites.json", Data: archived},
+		{Name: "webhook-dead-letters.json", Data: deadLetters},
+	}, nil
+}
+
+// userDataExportHandler returns a signed archive of everything held about a user.
+// @Summary Export all data held about a user
+// @Description Zip archive with one JSON file per subsystem (favorites, tags, trash, history, recent events,
+// @Description archived favorites, undelivered webhooks), a manifest of their SHA-256 digests and
+// @Description manifest.sig, an HMAC-SHA256 of the manifest under the server's signing key.
+// @Tags admin
+// @Produce application/zip
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 200 {file} file "Signed zip archive"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 501 {string} string "Signing key not configured"
+// @Router /users/{id}/data-export [get]
+func (api *API) userDataExportHandler(w http.ResponseWriter, r *http.Request) {
+	if len(api.SigningKey) == 0 {
+		http.Error(w, "data export not enabled", http.StatusNotImplemented)
+		return
+	}
+	userID := mux.Vars(r)["id"]
+	files, err := api.userDataFiles(r, userID)
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to collect user data", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := gdpr.WriteArchive(&buf, userID, files, api.SigningKey, time.Now()); err != nil {
+		http.Error(w, "failed to build archive", http.StatusInternalServerError)
+		return
+	}
+	filename := fmt.Sprintf("user-data-%s.zip", unsafeFilename.ReplaceAllString(userID, "_"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Write(buf.Bytes())
+}
+
+// eraseUserHandler deletes everything held about a user.
+// @Summary Erase a user
+// @Description Deletes the user's favorites, trash, history, recent events, archived favorites and
+// @Description undelivered webhooks, and records a receipt in the erasure ledger. The receipt identifies
+// @Description the user only by a keyed hash and is chained to the previous receipt.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "User ID"
+// @Success 200 {object} gdpr.Receipt
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 501 {string} string "Erasure not enabled or not supported by store"
+// @Router /users/{id} [delete]
+func (api *API) eraseUserHandler(w http.ResponseWriter, r *http.Request) {
+	if api.Erasures == nil {
+		http.Error(w, "erasure not enabled", http.StatusNotImplemented)
+		return
+	}
+	eraser, ok := store.Find[store.UserEraser](api.Store)
+	if !ok {
+		http.Error(w, "erasure not supported by store", http.StatusNotImplemented)
+		return
+	}
+
+	userID := mux.Vars(r)["id"]
+	erased, err := eraser.EraseUser(r.Context(), userID)
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to erase user", http.StatusInternalServerError)
+		return
+	}
+	if api.Orphans != nil {
+		erased["archivedFavorites"] = api.Orphans.EraseUser(userID)
+	}
+	if api.Webhooks != nil {
+		erased["webhookDeadLetters"], erased["webhookPendingDeliveries"] = api.Webhooks.EraseUser(userID)
+	}
+
+	receipt, err := api.Erasures.Record(userID, erased, time.Now())
+	if err != nil {
+		http.Error(w, "user erased but the receipt could not be recorded", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(receipt)
+}
+
+// ErasureLedger is the erasure ledger with its verification status.
+type ErasureLedger struct {
+	Verified bool           `json:"verified"`
+	Error    string         `json:"error,omitempty"`
+	Receipts []gdpr.Receipt `json:"receipts"`
+}
+
+// listErasuresHandler returns the erasure ledger.
+// @Summary List erasure receipts
+// @Description Every erasure receipt, oldest first, and whether the receipt chain verifies.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} api.ErasureLedger
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 501 {string} string "Erasure not enabled"
+// @Router /admin/erasures [get]
+func (api *API) listErasuresHandler(w http.ResponseWriter, r *http.Request) {
+	if api.Erasures == nil {
+		http.Error(w, "erasure not enabled", http.StatusNotImplemented)
+		return
+	}
+	ledger := ErasureLedger{Verified: true, Receipts: api.Erasures.Receipts()}
+	if err := api.Erasures.Verify(); err != nil {
+		ledger.Verified, ledger.Error = false, err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ledger)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/gdpr"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestUserDataExportAndErasure(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("gdpr-1", models.Chart{AssetBase: models.AssetBase{ID: "gdpr-1", Name: "One"}, ChartType: "bar"})
+	key := []byte("signing-key")
+	ledger, _ := gdpr.NewLedger(key, "")
+
+	bus := events.NewBus(0)
+	h := history.NewStore(events.NewStore(store.NewMemoryStore(), bus))
+	api := &API{Store: h, History: h, Events: bus, SigningKey: key, Erasures: ledger, AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	ctx := context.Background()
+	h.AddFavorite(ctx, "u1", "gdpr-1", "#private note")
+	h.AddFavorite(ctx, "u2", "gdpr-1", "")
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/users/u1/data-export", nil))
+	if res.Code != http.StatusOK || res.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("export: expected a zip, got %d %q", res.Code, res.Header().Get("Content-Type"))
+	}
+	body := res.Body.Bytes()
+	if _, err := gdpr.VerifyArchive(bytes.NewReader(body), int64(len(body)), key); err != nil {
+		t.Fatalf("archive does not verify: %v", err)
+	}
+	zr, _ := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	contents := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		contents[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	var tags map[string][]string
+	json.Unmarshal(contents["tags.json"], &tags)
+	if !bytes.Contains(contents["favorites.json"], []byte("#private note")) || len(tags["private"]) != 1 || !bytes.Contains(contents["history.json"], []byte("gdpr-1")) {
+		t.Errorf("archive is missing user data: %s", contents["favorites.json"])
+	}
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("DELETE", "/users/u1", nil))
+	var receipt gdpr.Receipt
+	json.NewDecoder(res.Body).Decode(&receipt)
+	if res.Code != http.StatusOK || receipt.Subject != ledger.Subject("u1") || receipt.Erased["favorites"] != 1 || receipt.Erased["history"] != 1 {
+		t.Fatalf("erase: %d %+v", res.Code, receipt)
+	}
+	if favs, _ := h.ListFavorites(ctx, "u1"); len(favs) != 0 {
+		t.Errorf("favorites survived erasure: %+v", favs)
+	}
+	if page := h.History("u1", 0, 0); len(page.Entries) != 0 {
+		t.Errorf("history survived erasure: %+v", page.Entries)
+	}
+	if recent := bus.Recent("u1"); len(recent) != 0 {
+		t.Errorf("events survived erasure: %+v", recent)
+	}
+	if favs, _ := h.ListFavorites(ctx, "u2"); len(favs) != 1 {
+		t.Errorf("other users must be untouched, got %+v", favs)
+	}
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/erasures", nil))
+	var l ErasureLedger
+	json.NewDecoder(res.Body).Decode(&l)
+	if !l.Verified || len(l.Receipts) != 1 {
+		t.Errorf("unexpected ledger %+v", l)
+	}
+
+	if res := executeRequest(r, "DELETE", "/users/u2", nil); res.Code != http.StatusUnauthorized {
+		t.Errorf("erasure must require the admin token, got %d", res.Code)
+	}
+}
+
+func TestUserDataDisabled(t *testing.T) {
+	api := &API{Store: store.NewMemoryStore(), AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+	for _, req := range []*http.Request{adminRequest("GET", "/users/u1/data-export", nil), adminRequest("DELETE", "/users/u1", nil)} {
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		if res.Code != http.StatusNotImplemented {
+			t.Errorf("%s %s: expected 501, got %d", req.Method, req.URL.Path, res.Code)
+		}
+	}
+}
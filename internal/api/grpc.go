@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+	favoritesv1 "my-solution/pkg/pb/favorites/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RegisterGRPC registers the favorites gRPC service on s. It serves the
+// same store and catalog as the REST routes, with the same validation,
+// deadlines and actor attribution.
+func (api *API) RegisterGRPC(s grpc.ServiceRegistrar) {
+	favoritesv1.RegisterFavoritesServer(s, &grpcServer{api: api})
+}
+
+type grpcServer struct {
+	favoritesv1.UnimplementedFavoritesServer
+	api *API
+}
+
+// actorContext attributes writes in ctx to the x-actor metadata value or,
+// like X-Actor on the REST routes, to userID if none was given. x-actor is
+// only honoured alongside the admin bearer token in authorization.
+func (s *grpcServer) actorContext(ctx context.Context, userID string) context.Context {
+	var actor, auth string
+	if v := metadata.ValueFromIncomingContext(ctx, "authorization"); len(v) > 0 {
+		auth = v[0]
+	}
+	if v := metadata.ValueFromIncomingContext(ctx, "x-actor"); len(v) > 0 && s.api.isAdmin(auth) {
+		actor = v[0]
+	}
+	return attribute(ctx, actor, userID)
+}
+
+// grpcError converts a store error to a gRPC status error. Errors without
+// a more specific code are reported as Internal with msg.
+func grpcError(err error, msg string) error {
+	switch {
+	case errors.Is(err, store.ErrAssetNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, store.ErrAlreadyFavorited):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, msg)
+}
+
+var (
+	errMissingUserID  = status.Error(codes.InvalidArgument, "user_id is required")
+	errMissingAssetID = status.Error(codes.InvalidArgument, "asset_id is required")
+	errNotInCatalog   = status.Error(codes.NotFound, "asset not found in catalog")
+)
+
+// checkIDs validates the user and asset IDs of a favorite request.
+func checkIDs(userID, assetID string) error {
+	if userID == "" {
+		return errMissingUserID
+	}
+	if assetID == "" {
+		return errMissingAssetID
+	}
+	return nil
+}
+
+func (s *grpcServer) AddFavorite(ctx context.Context, req *favoritesv1.AddFavoriteRequest) (*favoritesv1.AddFavoriteResponse, error) {
+	if err := checkIDs(req.UserId, req.AssetId); err != nil {
+		return nil, err
+	}
+	assetID, ok := catalog.Global.Resolve(req.AssetId)
+	if !ok {
+		return nil, errNotInCatalog
+	}
+	ctx, cancel := context.WithTimeout(s.actorContext(ctx, req.UserId), writeTimeout)
+	defer cancel()
+	if err := s.api.Store.AddFavorite(ctx, req.UserId, assetID, req.Description); err != nil {
+		return nil, grpcError(err, "failed to add favorite")
+	}
+	return &favoritesv1.AddFavoriteResponse{}, nil
+}
+
+func (s *grpcServer) GetFavorite(ctx context.Context, req *favoritesv1.GetFavoriteRequest) (*favoritesv1.Favorite, error) {
+	if err := checkIDs(req.UserId, req.AssetId); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+	fav, err := s.api.Store.GetFavorite(ctx, req.UserId, req.AssetId)
+	if err != nil {
+		return nil, grpcError(err, "failed to get favorite")
+	}
+	return toProtoFavorite(fav), nil
+}
+
+func (s *grpcServer) ListFavorites(req *favoritesv1.ListFavoritesRequest, stream grpc.ServerStreamingServer[favoritesv1.Favorite]) error {
+	if req.UserId == "" {
+		return errMissingUserID
+	}
+	ctx, cancel := context.WithTimeout(stream.Context(), readTimeout)
+	defer cancel()
+
+	var (
+		favorites []models.FavoriteWithAsset
+		err       error
+	)
+	if req.IncludeUnavailable {
+		lister, ok := store.Find[store.UnavailableLister](s.api.Store)
+		if !ok {
+			return status.Error(codes.Unimplemented, "include_unavailable not supported by store")
+		}
+		favorites, err = lister.ListFavoritesWithUnavailable(ctx, req.UserId)
+	} else {
+		favorites, err = s.api.Store.ListFavorites(ctx, req.UserId)
+	}
+	if err != nil {
+		return grpcError(err, "failed to list favorites")
+	}
+	for _, fav := range favorites {
+		if err := stream.Send(toProtoFavorite(fav)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *grpcServer) UpsertFavorite(ctx context.Context, req *favoritesv1.UpsertFavoriteRequest) (*favoritesv1.UpsertFavoriteResponse, error) {
+	if err := checkIDs(req.UserId, req.AssetId); err != nil {
+		return nil, err
+	}
+	assetID, ok := catalog.Global.Resolve(req.AssetId)
+	if !ok {
+		return nil, errNotInCatalog
+	}
+	ctx, cancel := context.WithTimeout(s.actorContext(ctx, req.UserId), writeTimeout)
+	defer cancel()
+	result, err := s.api.Store.UpsertFavorite(ctx, req.UserId, assetID, req.Description)
+	if err != nil {
+		return nil, grpcError(err, "failed to save favorite")
+	}
+	return &favoritesv1.UpsertFavoriteResponse{Created: result == store.Created}, nil
+}
+
+func (s *grpcServer) RemoveFavorite(ctx context.Context, req *favoritesv1.RemoveFavoriteRequest) (*favoritesv1.RemoveFavoriteResponse, error) {
+	if err := checkIDs(req.UserId, req.AssetId); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(s.actorContext(ctx, req.UserId), writeTimeout)
+	defer cancel()
+	result, err := s.api.Store.RemoveFavorite(ctx, req.UserId, req.AssetId)
+	if err != nil {
+		return nil, grpcError(err, "failed to remove favorite")
+	}
+	return &favoritesv1.RemoveFavoriteResponse{Removed: result == store.Removed}, nil
+}
+
+func (s *grpcServer) EditFavoriteDescription(ctx context.Context, req *favoritesv1.EditFavoriteDescriptionRequest) (*favoritesv1.EditFavoriteDescriptionResponse, error) {
+	if err := checkIDs(req.UserId, req.AssetId); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(s.actorContext(ctx, req.UserId), writeTimeout)
+	defer cancel()
+	if err := s.api.Store.EditFavoriteDescription(ctx, req.UserId, req.AssetId, req.Description); err != nil {
+		return nil, grpcError(err, "failed to update description")
+	}
+	return &favoritesv1.EditFavoriteDescriptionResponse{}, nil
+}
+
+func (s *grpcServer) GetAsset(ctx context.Context, req *favoritesv1.GetAssetRequest) (*favoritesv1.Asset, error) {
+	a, ok := catalog.Global.Get(req.Id)
+	if !ok {
+		return nil, errNotInCatalog
+	}
+	return toProtoAsset(a), nil
+}
+
+func (s *grpcServer) ListAssets(ctx context.Context, req *favoritesv1.ListAssetsRequest) (*favoritesv1.ListAssetsResponse, error) {
+	var assets []models.Asset
+	if len(req.Ids) == 0 {
+		assets = catalog.Global.List()
+	} else {
+		found := catalog.Global.GetMany(req.Ids)
+		for _, id := range req.Ids {
+			if a, ok := found[id]; ok {
+				assets = append(assets, a)
+			}
+		}
+	}
+	resp := &favoritesv1.ListAssetsResponse{Assets: make([]*favoritesv1.Asset, len(assets))}
+	for i, a := range assets {
+		resp.Assets[i] = toProtoAsset(a)
+	}
+	return resp, nil
+}
+
+func toProtoFavorite(fav models.FavoriteWithAsset) *favoritesv1.Favorite {
+	pb := &favoritesv1.Favorite{
+		AssetId:     fav.AssetID,
+		Description: fav.Description,
+		CreatedAt:   timestamppb.New(fav.CreatedAt),
+		Status:      fav.Status,
+	}
+	if fav.Asset != nil {
+		pb.Asset = toProtoAsset(fav.Asset)
+	}
+	return pb
+}
+
+// toProtoAsset converts a catalog asset, stored as a value or a pointer,
+// to its protobuf message.
+func toProtoAsset(a models.Asset) *favoritesv1.Asset {
+	pb := &favoritesv1.Asset{Id: a.GetID(), Name: a.GetName(), Description: a.GetDescription()}
+	switch v := a.(type) {
+	case *models.Chart:
+		a = *v
+	case *models.Insight:
+		a = *v
+	case *models.Audience:
+		a = *v
+	}
+	switch v := a.(type) {
+	case models.Chart:
+		pb.Kind = &favoritesv1.Asset_Chart{Chart: &favoritesv1.Chart{ChartType: v.ChartType, DataSource: v.DataSource}}
+	case models.Insight:
+		pb.Kind = &favoritesv1.Asset_Insight{Insight: &favoritesv1.Insight{Metric: v.Metric, Value: v.Value}}
+	case models.Audience:
+		pb.Kind = &favoritesv1.Asset_Audience{Audience: &favoritesv1.Audience{Segment: v.Segment, Size: int64(v.Size)}}
+	}
+	return pb
+}
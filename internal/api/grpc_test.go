@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+	favoritesv1 "my-solution/pkg/pb/favorites/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func setupGRPC(t *testing.T, api *API) favoritesv1.FavoritesClient {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	api.RegisterGRPC(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return favoritesv1.NewFavoritesClient(conn)
+}
+
+func TestGRPCFavorites(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("g1", &models.Chart{AssetBase: models.AssetBase{ID: "g1", Name: "Chart"}, ChartType: "bar"})
+	catalog.Global.AddAsset("g2", models.Audience{AssetBase: models.AssetBase{ID: "g2", Name: "Audience"}, Segment: "new", Size: 7})
+	h := history.NewStore(store.NewMemoryStore())
+	c := setupGRPC(t, &API{Store: h, History: h, AdminToken: testAdminToken})
+	ctx := context.Background()
+
+	if _, err := c.AddFavorite(ctx, &favoritesv1.AddFavoriteRequest{UserId: "u1", AssetId: "g1", Description: "one"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.AddFavorite(ctx, &favoritesv1.AddFavoriteRequest{UserId: "u1", AssetId: "g1"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	_, err = c.AddFavorite(ctx, &favoritesv1.AddFavoriteRequest{UserId: "u1", AssetId: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	_, err = c.AddFavorite(ctx, &favoritesv1.AddFavoriteRequest{AssetId: "g1"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	// Without the admin token x-actor is ignored.
+	spoofCtx := metadata.AppendToOutgoingContext(ctx, "x-actor", "mallory")
+	up, err := c.UpsertFavorite(spoofCtx, &favoritesv1.UpsertFavoriteRequest{UserId: "u1", AssetId: "g2", Description: "two"})
+	if err != nil || !up.Created {
+		t.Fatalf("upsert: %+v, %v", up, err)
+	}
+
+	actorCtx := metadata.AppendToOutgoingContext(ctx, "x-actor", "support", "authorization", "Bearer "+testAdminToken)
+	if _, err := c.EditFavoriteDescription(actorCtx, &favoritesv1.EditFavoriteDescriptionRequest{UserId: "u1", AssetId: "g1", Description: "edited"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.EditFavoriteDescription(ctx, &favoritesv1.EditFavoriteDescriptionRequest{UserId: "u1", AssetId: "nope"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	stream, err := c.ListFavorites(ctx, &favoritesv1.ListFavoritesRequest{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var favs []*favoritesv1.Favorite
+	for {
+		fav, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		favs = append(favs, fav)
+	}
+	if len(favs) != 2 {
+		t.Fatalf("expected 2 favorites, got %+v", favs)
+	}
+	byID := map[string]*favoritesv1.Favorite{favs[0].AssetId: favs[0], favs[1].AssetId: favs[1]}
+	if f := byID["g1"]; f.Description != "edited" || f.Asset.GetChart().GetChartType() != "bar" {
+		t.Errorf("unexpected chart favorite %+v", f)
+	}
+	if f := byID["g2"]; f.Asset.GetAudience().GetSize() != 7 || f.CreatedAt.AsTime().IsZero() {
+		t.Errorf("unexpected audience favorite %+v", f)
+	}
+
+	got, err := c.GetFavorite(ctx, &favoritesv1.GetFavoriteRequest{UserId: "u1", AssetId: "g2"})
+	if err != nil || got.Description != "two" {
+		t.Fatalf("get: %+v, %v", got, err)
+	}
+
+	rm, err := c.RemoveFavorite(ctx, &favoritesv1.RemoveFavoriteRequest{UserId: "u1", AssetId: "g2"})
+	if err != nil || !rm.Removed {
+		t.Fatalf("remove: %+v, %v", rm, err)
+	}
+	rm, err = c.RemoveFavorite(ctx, &favoritesv1.RemoveFavoriteRequest{UserId: "u1", AssetId: "g2"})
+	if err != nil || rm.Removed {
+		t.Fatalf("second remove: %+v, %v", rm, err)
+	}
+	_, err = c.GetFavorite(ctx, &favoritesv1.GetFavoriteRequest{UserId: "u1", AssetId: "g2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	// Writes are attributed like the REST routes: x-actor from admins, else the user.
+	page := h.History("u1", 0, 0)
+	if len(page.Entries) != 4 {
+		t.Fatalf("expected 4 history entries, got %+v", page.Entries)
+	}
+	if e := page.Entries[1]; e.Action != history.Edited || e.Actor != "support" {
+		t.Errorf("unexpected edit entry %+v", e)
+	}
+	if e := page.Entries[0]; e.Action != history.Removed || e.Actor != "u1" {
+		t.Errorf("unexpected remove entry %+v", e)
+	}
+	if e := page.Entries[2]; e.Action != history.Added || e.Actor != "u1" {
+		t.Errorf("x-actor without the admin token must be ignored, got %+v", e)
+	}
+}
+
+func TestGRPCAssets(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("i1", models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "Insight"}, Metric: "m", Value: "v"})
+	catalog.Global.AddAsset("c1", models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Chart"}, ChartType: "line"})
+	c := setupGRPC(t, &API{Store: store.NewMemoryStore()})
+	ctx := context.Background()
+
+	a, err := c.GetAsset(ctx, &favoritesv1.GetAssetRequest{Id: "i1"})
+	if err != nil || a.Name != "Insight" || a.GetInsight().GetMetric() != "m" {
+		t.Fatalf("get asset: %+v, %v", a, err)
+	}
+	if _, err := c.GetAsset(ctx, &favoritesv1.GetAssetRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	list, err := c.ListAssets(ctx, &favoritesv1.ListAssetsRequest{Ids: []string{"c1", "missing", "i1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Assets) != 2 || list.Assets[0].Id != "c1" || list.Assets[1].Id != "i1" {
+		t.Errorf("unexpected assets %+v", list.Assets)
+	}
+	list, err = c.ListAssets(ctx, &favoritesv1.ListAssetsRequest{})
+	if err != nil || len(list.Assets) != 2 {
+		t.Errorf("expected whole catalog, got %+v, %v", list, err)
+	}
+}
@@ -4,9 +4,20 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"my-solution/internal/catalog"
+	"my-solution/internal/dashboard"
+	"my-solution/internal/events"
+	"my-solution/internal/gdpr"
+	"my-solution/internal/gql"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/orphans"
+	"my-solution/internal/recommend"
 	"my-solution/internal/store"
+	"my-solution/internal/webhooks"
 	"my-solution/pkg/health"
 
 	"github.com/gorilla/mux"
@@ -15,17 +26,119 @@ import (
 // API represents the API server with its store backend.
 type API struct {
 	Store store.Store
+
+	// Events, when set, backs the favorites change feed. Store should be
+	// wrapped with events.NewStore on the same bus so writes are published.
+	Events *events.Bus
+
+	// Recommender, when set, backs the recommendations endpoint. It should
+	// observe the same event bus as Events.
+	Recommender *recommend.Engine
+
+	// History, when set, backs the favorites history and undo endpoints.
+	// Store should be History itself or wrap it so writes are recorded.
+	History *history.Store
+
+	// Dashboards, when set, caches dashboards between requests. It should
+	// observe the same event bus as Events so that it sees every write.
+	Dashboards *dashboard.Cache
+
+	// Orphans, when set, enables the orphaned favorite admin endpoints.
+	Orphans *orphans.Sweeper
+
+	// Webhooks, when set, enables the webhook admin endpoints.
+	Webhooks *webhooks.Dispatcher
+
+	// SigningKey, when set, enables the signed user data export.
+	SigningKey []byte
+
+	// Erasures, when set, enables user erasure and records its receipts.
+	Erasures *gdpr.Ledger
+
+	// AdminToken is the bearer token required by /admin endpoints.
+	// Admin endpoints are disabled when it is empty.
+	AdminToken string
+
+	// Sunset, when set, is announced on the unversioned routes as the date
+	// they will be removed.
+	Sunset time.Time
 }
 
-// RegisterHandlers sets up all API routes on the provided router.
+// RegisterHandlers mounts the API under /v1 and, for clients that predate
+// versioning, at the root with Deprecation and Sunset headers. /healthz
+// stays at the root undeprecated for load balancers and probes, as does
+// /graphql, whose schema evolves without URL versions.
+//
+// Later versions are mounted by their own packages under their own
+// prefix, so RegisterV1 routes never change shape.
 func (api *API) RegisterHandlers(r *mux.Router) {
-	// Browse available assets (catalog)
-	r.HandleFunc("/assets", api.listAssetsHandler).Methods("GET")
 	r.HandleFunc("/healthz", healthHandler).Methods("GET")
-	r.HandleFunc("/users/{id}/favorites", api.listFavoritesHandler).Methods("GET")
-	r.HandleFunc("/users/{id}/favorites", api.addFavoriteHandler).Methods("POST")
-	r.HandleFunc("/users/{id}/favorites/{assetID}", api.removeFavoriteHandler).Methods("DELETE")
-	r.HandleFunc("/users/{id}/favorites/{assetID}", api.editFavoriteHandler).Methods("PATCH")
+	r.Handle("/graphql", api.withActor(withTimeout(writeTimeout, gql.NewHandler(api.Store).ServeHTTP))).Methods("POST")
+	api.RegisterV1(r.PathPrefix("/v1").Subrouter())
+
+	legacy := r.NewRoute().Subrouter()
+	legacy.Use(api.deprecated)
+	api.RegisterV1(legacy)
+
+	r.NotFoundHandler = methodNotAllowed(r)
+	r.MethodNotAllowedHandler = r.NotFoundHandler
+}
+
+// RegisterV1 sets up all v1 API routes on the provided router.
+func (api *API) RegisterV1(r *mux.Router) {
+	r.Use(api.withActor)
+
+	// Browse available assets (catalog)
+	r.HandleFunc("/assets", withTimeout(readTimeout, api.listAssetsHandler)).Methods("GET")
+	r.HandleFunc("/assets/popular", withTimeout(readTimeout, api.popularAssetsHandler)).Methods("GET")
+	r.HandleFunc("/assets/trending", withTimeout(readTimeout, api.trendingAssetsHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}", api.requireAdmin(withTimeout(writeTimeout, api.eraseUserHandler))).Methods("DELETE")
+	r.HandleFunc("/users/{id}/dashboard", withTimeout(readTimeout, api.dashboardHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/data-export", api.requireAdmin(withTimeout(readTimeout, api.userDataExportHandler))).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites", withTimeout(readTimeout, api.listFavoritesHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites", withTimeout(writeTimeout, api.addFavoriteHandler)).Methods("POST")
+	r.HandleFunc("/users/{id}/favorites/events", api.favoriteEventsHandler).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites/export", withTimeout(readTimeout, api.exportFavoritesHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites/import", withTimeout(writeTimeout, api.importFavoritesHandler)).Methods("POST")
+	r.HandleFunc("/users/{id}/favorites/history", withTimeout(readTimeout, api.favoriteHistoryHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites/undo", withTimeout(writeTimeout, api.undoFavoriteHandler)).Methods("POST")
+	r.HandleFunc("/users/{id}/favorites/trash", withTimeout(readTimeout, api.listTrashHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites/trash/{assetID}/restore", withTimeout(writeTimeout, api.restoreFavoriteHandler)).Methods("POST")
+	r.HandleFunc("/users/{id}/favorites/ws", api.favoritesSyncHandler).Methods("GET")
+	r.HandleFunc("/users/{id}/recommendations", withTimeout(readTimeout, api.recommendationsHandler)).Methods("GET")
+	r.HandleFunc("/users/{id}/favorites/{assetID}", withTimeout(readTimeout, api.getFavoriteHandler)).Methods("GET", "HEAD")
+	r.HandleFunc("/users/{id}/favorites/{assetID}", withTimeout(writeTimeout, api.putFavoriteHandler)).Methods("PUT")
+	r.HandleFunc("/users/{id}/favorites/{assetID}", withTimeout(writeTimeout, api.removeFavoriteHandler)).Methods("DELETE")
+	r.HandleFunc("/users/{id}/favorites/{assetID}", withTimeout(writeTimeout, api.editFavoriteHandler)).Methods("PATCH")
+
+	// Admin: webhook subscriptions and deliveries
+	r.HandleFunc("/admin/webhooks", api.requireAdmin(api.createWebhookHandler)).Methods("POST")
+	r.HandleFunc("/admin/webhooks", api.requireAdmin(api.listWebhooksHandler)).Methods("GET")
+	r.HandleFunc("/admin/webhooks/dead-letters", api.requireAdmin(api.listDeadLettersHandler)).Methods("GET")
+	r.HandleFunc("/admin/webhooks/dead-letters/{deliveryID}/redeliver", api.requireAdmin(api.redeliverHandler)).Methods("POST")
+	r.HandleFunc("/admin/webhooks/{subID}", api.requireAdmin(api.getWebhookHandler)).Methods("GET")
+	r.HandleFunc("/admin/webhooks/{subID}", api.requireAdmin(api.deleteWebhookHandler)).Methods("DELETE")
+	r.HandleFunc("/admin/webhooks/{subID}/deliveries", api.requireAdmin(api.webhookDeliveriesHandler)).Methods("GET")
+
+	// Admin: replaced assets
+	r.HandleFunc("/admin/catalog/aliases", api.requireAdmin(api.listAliasesHandler)).Methods("GET")
+	r.HandleFunc("/admin/catalog/migrate-aliases", api.requireAdmin(api.migrateAliasesHandler)).Methods("POST")
+
+	// Admin: catalog asset management
+	r.HandleFunc("/admin/catalog/assets", api.requireAdmin(api.listCatalogAssetsHandler)).Methods("GET")
+	r.HandleFunc("/admin/catalog/assets", api.requireAdmin(api.createCatalogAssetHandler)).Methods("POST")
+	r.HandleFunc("/admin/catalog/assets/{assetID}", api.requireAdmin(api.updateCatalogAssetHandler)).Methods("PUT")
+	r.HandleFunc("/admin/catalog/assets/{assetID}", api.requireAdmin(api.deleteCatalogAssetHandler)).Methods("DELETE")
+	r.HandleFunc("/admin/catalog/assets/{assetID}/deprecate", api.requireAdmin(api.deprecateCatalogAssetHandler)).Methods("POST")
+	r.HandleFunc("/admin/catalog/assets/{assetID}/undeprecate", api.requireAdmin(api.undeprecateCatalogAssetHandler)).Methods("POST")
+
+	// Admin: user erasure receipts
+	r.HandleFunc("/admin/erasures", api.requireAdmin(api.listErasuresHandler)).Methods("GET")
+
+	// Admin: favorites whose asset left the catalog
+	r.HandleFunc("/admin/orphans", api.requireAdmin(api.orphanReportHandler)).Methods("GET")
+	r.HandleFunc("/admin/orphans/archive", api.requireAdmin(api.orphanArchiveHandler)).Methods("GET")
+	r.HandleFunc("/admin/orphans/sweep", api.requireAdmin(api.orphanSweepHandler)).Methods("POST")
 }
 
 // healthHandler returns service health and version.
@@ -41,31 +154,130 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 
 // listAssetsHandler returns the catalog of all available assets.
 // @Summary List all available assets
-// @Description Get a list of all assets in the catalog
+// @Description Get a list of all assets in the catalog. When the store maintains a
+// @Description popularity index, each asset includes a favoriteCount field. With forUser,
+// @Description each asset also includes isFavorite and, when favorited, the user's description.
 // @Tags assets
 // @Produce json
+// @Param forUser query string false "Mark the assets this user has favorited"
 // @Success 200 {array} object
+// @Failure 500 {string} string "Internal server error"
 // @Router /assets [get]
 func (api *API) listAssetsHandler(w http.ResponseWriter, r *http.Request) {
 	assets := catalog.Global.List()
+	forUser := r.URL.Query().Get("forUser")
+
+	idx, hasIndex := store.Find[store.PopularityIndex](api.Store)
+	if !hasIndex && forUser == "" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(assets)
+		return
+	}
+
+	var counts map[string]int
+	if hasIndex {
+		var err error
+		if counts, err = idx.FavoriteCounts(r.Context()); err != nil {
+			if writeContextError(w, err) {
+				return
+			}
+			http.Error(w, "failed to load favorite counts", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var favorited map[string]string
+	if forUser != "" {
+		var err error
+		if favorited, err = api.favoriteDescriptions(r, forUser); err != nil {
+			if writeContextError(w, err) {
+				return
+			}
+			http.Error(w, "failed to load favorites", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	result := make([]catalogEntry, len(assets))
+	for i, asset := range assets {
+		extra := make(map[string]any, 3)
+		if hasIndex {
+			extra["favoriteCount"] = counts[asset.GetID()]
+		}
+		if forUser != "" {
+			desc, ok := favorited[asset.GetID()]
+			extra["isFavorite"] = ok
+			if ok {
+				extra["favoriteDescription"] = desc
+			}
+		}
+		result[i] = catalogEntry{asset: asset, extra: extra}
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(assets)
+	json.NewEncoder(w).Encode(result)
+}
+
+// favoriteDescriptions returns the user's favorited asset IDs mapped to
+// their descriptions, from the store's membership index if it has one.
+func (api *API) favoriteDescriptions(r *http.Request, userID string) (map[string]string, error) {
+	if idx, ok := store.Find[store.MembershipIndex](api.Store); ok {
+		return idx.FavoriteDescriptions(r.Context(), userID)
+	}
+	favorites, err := api.Store.ListFavorites(r.Context(), userID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(favorites))
+	for _, fav := range favorites {
+		result[fav.AssetID] = fav.Description
+	}
+	return result, nil
 }
 
 // listFavoritesHandler retrieves all favorites for a user.
 // @Summary List user's favorites
-// @Description Get all favorites for a specific user
+// @Description Get all favorites for a specific user. Favorites whose asset has left the catalog
+// @Description are omitted unless includeUnavailable is set, in which case they carry status "unavailable".
 // @Tags favorites
 // @Param id path string true "User ID"
+// @Param includeUnavailable query bool false "Include favorites whose asset is no longer in the catalog"
 // @Produce json
 // @Success 200 {array} object
+// @Failure 400 {string} string "Bad request"
 // @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
 // @Router /users/{id}/favorites [get]
 func (api *API) listFavoritesHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
-	favorites, err := api.Store.ListFavorites(userID)
+
+	includeUnavailable := false
+	if v := r.URL.Query().Get("includeUnavailable"); v != "" {
+		var err error
+		if includeUnavailable, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "invalid includeUnavailable parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var (
+		favorites []models.FavoriteWithAsset
+		err       error
+	)
+	if includeUnavailable {
+		lister, ok := store.Find[store.UnavailableLister](api.Store)
+		if !ok {
+			http.Error(w, "includeUnavailable not supported by store", http.StatusNotImplemented)
+			return
+		}
+		favorites, err = lister.ListFavoritesWithUnavailable(r.Context(), userID)
+	} else {
+		favorites, err = api.Store.ListFavorites(r.Context(), userID)
+	}
 	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
 		http.Error(w, "failed to list favorites", http.StatusInternalServerError)
 		return
 	}
@@ -92,6 +304,7 @@ type AddFavoriteRequest struct {
 // @Failure 404 {string} string "Asset not found"
 // @Failure 409 {string} string "Asset already favorited"
 // @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
 // @Router /users/{id}/favorites [post]
 func (api *API) addFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -108,19 +321,23 @@ func (api *API) addFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate asset exists in catalog
-	if _, ok := catalog.Global.Get(req.AssetID); !ok {
+	// Validate asset exists in catalog, storing retired IDs under their replacement
+	assetID, ok := catalog.Global.Resolve(req.AssetID)
+	if !ok {
 		http.Error(w, "asset not found in catalog", http.StatusNotFound)
 		return
 	}
 
 	// Add favorite directly
-	err := api.Store.AddFavorite(userID, req.AssetID, req.Description)
+	err := api.Store.AddFavorite(r.Context(), userID, assetID, req.Description)
 	if err != nil {
-		if err.Error() == "asset already favorited" {
+		if errors.Is(err, store.ErrAlreadyFavorited) {
 			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
+		if writeContextError(w, err) {
+			return
+		}
 		http.Error(w, "failed to add favorite", http.StatusInternalServerError)
 		return
 	}
@@ -128,25 +345,138 @@ func (api *API) addFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
+// getFavoriteHandler returns one of the user's favorites.
+// @Summary Get a favorite
+// @Description Get one favorite with its asset. HEAD returns the same status without a body, as a
+// @Description cheap check of whether the asset is favorited. A favorite whose asset has left the
+// @Description catalog is returned with status "unavailable".
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Param assetID path string true "Asset ID"
+// @Success 200 {object} models.FavoriteWithAsset
+// @Failure 404 {string} string "Favorite not found"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
+// @Router /users/{id}/favorites/{assetID} [get]
+// @Router /users/{id}/favorites/{assetID} [head]
+func (api *API) getFavoriteHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	fav, err := api.Store.GetFavorite(r.Context(), vars["id"], vars["assetID"])
+	if err != nil {
+		if errors.Is(err, store.ErrAssetNotFound) {
+			http.Error(w, "favorite not found", http.StatusNotFound)
+			return
+		}
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to get favorite", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	json.NewEncoder(w).Encode(fav)
+}
+
+// PutFavoriteRequest defines the body for adding or updating a favorite.
+type PutFavoriteRequest struct {
+	Description string `json:"description"`
+}
+
+// putFavoriteHandler adds an asset to the user's favorites or updates it.
+// @Summary Add or update a favorite
+// @Description Idempotent form of add: favorites the asset with the given description, or sets the
+// @Description description if it is already favorited.
+// @Tags favorites
+// @Accept json
+// @Param id path string true "User ID"
+// @Param assetID path string true "Asset ID"
+// @Param request body api.PutFavoriteRequest true "Description"
+// @Success 200 {string} string "Updated"
+// @Success 201 {string} string "Created"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Asset not found"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
+// @Router /users/{id}/favorites/{assetID} [put]
+func (api *API) putFavoriteHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["id"]
+	assetID := vars["assetID"]
+
+	var req PutFavoriteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	// Validate asset exists in catalog, storing retired IDs under their replacement
+	assetID, ok := catalog.Global.Resolve(assetID)
+	if !ok {
+		http.Error(w, "asset not found in catalog", http.StatusNotFound)
+		return
+	}
+
+	result, err := api.Store.UpsertFavorite(r.Context(), userID, assetID, req.Description)
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to save favorite", http.StatusInternalServerError)
+		return
+	}
+	if result == store.Created {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
 // removeFavoriteHandler deletes an asset from the user's favorites.
 // @Summary Remove a favorite
-// @Description Remove an asset from user's favorites
+// @Description Remove an asset from user's favorites. Stores with a trash keep it restorable for a retention period.
+// @Description Removing an asset that is not favorited succeeds unless strict is set.
 // @Tags favorites
 // @Param id path string true "User ID"
 // @Param assetID path string true "Asset ID"
+// @Param strict query bool false "Return 404 if the asset is not favorited"
 // @Success 204 {string} string "No Content"
+// @Failure 400 {string} string "Bad request"
+// @Failure 404 {string} string "Favorite not found (strict only)"
 // @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
 // @Router /users/{id}/favorites/{assetID} [delete]
 func (api *API) removeFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userID := vars["id"]
 	assetID := vars["assetID"]
 
+	strict := false
+	if v := r.URL.Query().Get("strict"); v != "" {
+		var err error
+		if strict, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "invalid strict parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Remove favorite directly
-	if err := api.Store.RemoveFavorite(userID, assetID); err != nil {
+	result, err := api.Store.RemoveFavorite(r.Context(), userID, assetID)
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
 		http.Error(w, "failed to remove favorite", http.StatusInternalServerError)
 		return
 	}
+	if strict && result == store.NotFound {
+		http.Error(w, "favorite not found", http.StatusNotFound)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -167,6 +497,7 @@ type EditFavoriteRequest struct {
 // @Failure 400 {string} string "Bad request"
 // @Failure 404 {string} string "Favorite not found"
 // @Failure 500 {string} string "Internal server error"
+// @Failure 504 {string} string "Request timed out"
 // @Router /users/{id}/favorites/{assetID} [patch]
 func (api *API) editFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -180,11 +511,14 @@ func (api *API) editFavoriteHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update description directly
-	if err := api.Store.EditFavoriteDescription(userID, assetID, req.Description); err != nil {
+	if err := api.Store.EditFavoriteDescription(r.Context(), userID, assetID, req.Description); err != nil {
 		if errors.Is(err, store.ErrAssetNotFound) {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
+		if writeContextError(w, err) {
+			return
+		}
 		http.Error(w, "failed to update description", http.StatusInternalServerError)
 		return
 	}
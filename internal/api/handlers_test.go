@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -178,6 +179,73 @@ func TestEditNonExistentFavoriteHandler(t *testing.T) {
 	}
 }
 
+func TestGetAndHeadFavoriteHandler(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("get-chart", models.Chart{AssetBase: models.AssetBase{ID: "get-chart", Name: "Get"}, ChartType: "bar"})
+	r, _ := setupRouter()
+	executeRequest(r, "POST", "/users/u1/favorites", AddFavoriteRequest{AssetID: "get-chart", Description: "mine"})
+
+	res := executeRequest(r, "GET", "/users/u1/favorites/get-chart", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var fav map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&fav)
+	if fav["assetId"] != "get-chart" || fav["description"] != "mine" || fav["asset"] == nil {
+		t.Errorf("unexpected favorite %v", fav)
+	}
+
+	res = executeRequest(r, "HEAD", "/users/u1/favorites/get-chart", nil)
+	if res.Code != http.StatusOK || res.Body.Len() != 0 {
+		t.Errorf("HEAD: expected 200 with no body, got %d %q", res.Code, res.Body.String())
+	}
+	for _, method := range []string{"GET", "HEAD"} {
+		if res := executeRequest(r, method, "/users/u2/favorites/get-chart", nil); res.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404 for another user, got %d", method, res.Code)
+		}
+	}
+}
+
+func TestPutFavoriteHandler(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("put-chart", models.Chart{AssetBase: models.AssetBase{ID: "put-chart", Name: "Put"}, ChartType: "bar"})
+	r, s := setupRouter()
+
+	if res := executeRequest(r, "PUT", "/users/u1/favorites/put-chart", PutFavoriteRequest{Description: "first"}); res.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", res.Code)
+	}
+	if res := executeRequest(r, "PUT", "/users/u1/favorites/put-chart", PutFavoriteRequest{Description: "second"}); res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	favs, _ := s.ListFavorites(context.Background(), "u1")
+	if len(favs) != 1 || favs[0].Description != "second" {
+		t.Errorf("expected one favorite with the latest description, got %+v", favs)
+	}
+	if res := executeRequest(r, "PUT", "/users/u1/favorites/missing", PutFavoriteRequest{}); res.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for asset not in catalog, got %d", res.Code)
+	}
+}
+
+func TestStrictRemoveFavoriteHandler(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("del-chart", models.Chart{AssetBase: models.AssetBase{ID: "del-chart", Name: "Del"}, ChartType: "bar"})
+	r, _ := setupRouter()
+	executeRequest(r, "POST", "/users/u1/favorites", AddFavoriteRequest{AssetID: "del-chart"})
+
+	if res := executeRequest(r, "DELETE", "/users/u1/favorites/del-chart?strict=true", nil); res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", res.Code)
+	}
+	if res := executeRequest(r, "DELETE", "/users/u1/favorites/del-chart?strict=true", nil); res.Code != http.StatusNotFound {
+		t.Errorf("strict: expected 404 when not favorited, got %d", res.Code)
+	}
+	if res := executeRequest(r, "DELETE", "/users/u1/favorites/del-chart", nil); res.Code != http.StatusNoContent {
+		t.Errorf("non-strict: expected 204 when not favorited, got %d", res.Code)
+	}
+	if res := executeRequest(r, "DELETE", "/users/u1/favorites/del-chart?strict=maybe", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid strict, got %d", res.Code)
+	}
+}
+
 func TestAddInvalidFavoriteHandler(t *testing.T) {
 	r, _ := setupRouter()
 	userID := "userInvalid"
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"my-solution/internal/history"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultHistoryLimit is the page size of the history endpoint.
+const defaultHistoryLimit = 50
+
+// withActor tags the request context with who is making the change, for
+// the favorites history. Clients acting on behalf of someone else (e.g.
+// support tooling) set X-Actor along with the admin bearer token; without
+// the token X-Actor is ignored and the user in the path is assumed.
+func (api *API) withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var actor string
+		if api.isAdmin(r.Header.Get("Authorization")) {
+			actor = r.Header.Get("X-Actor")
+		}
+		r = r.WithContext(attribute(r.Context(), actor, mux.Vars(r)["id"]))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// attribute tags ctx with actor, or with userID if no actor was given.
+// REST and gRPC requests share this rule; callers pass an actor only for
+// requests authenticated as admin.
+func attribute(ctx context.Context, actor, userID string) context.Context {
+	if actor == "" {
+		actor = userID
+	}
+	if actor == "" {
+		return ctx
+	}
+	return history.WithActor(ctx, actor)
+}
+
+// favoriteHistoryHandler pages through a user's favorites history.
+// @Summary Favorites change history
+// @Description Every change to the user's favorites, most recent first. Pass the returned
+// @Description nextBefore as before to fetch the next page.
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Param limit query int false "Entries per page (1-500, default 50)"
+// @Param before query int false "Only entries with a lower seq"
+// @Success 200 {object} history.Page
+// @Failure 400 {string} string "Bad request"
+// @Failure 501 {string} string "History not enabled"
+// @Router /users/{id}/favorites/history [get]
+func (api *API) favoriteHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if api.History == nil {
+		http.Error(w, "history not enabled", http.StatusNotImplemented)
+		return
+	}
+	limit := defaultHistoryLimit
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 500 {
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	var before uint64
+	if v := q.Get("before"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid before parameter", http.StatusBadRequest)
+			return
+		}
+		before = n
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.History.History(mux.Vars(r)["id"], before, limit))
+}
+
+// undoFavoriteHandler reverts the user's most recent favorites change.
+// @Summary Undo the last favorites change
+// @Description Reverts the most recent change not already undone. Calling it again undoes the change before that.
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} history.Entry "The entry recording the revert"
+// @Failure 404 {string} string "Nothing to undo"
+// @Failure 409 {string} string "Favorite changed since"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 501 {string} string "History not enabled"
+// @Router /users/{id}/favorites/undo [post]
+func (api *API) undoFavoriteHandler(w http.ResponseWriter, r *http.Request) {
+	if api.History == nil {
+		http.Error(w, "history not enabled", http.StatusNotImplemented)
+		return
+	}
+	entry, err := api.History.Undo(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		switch {
+		case errors.Is(err, history.ErrNothingToUndo):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, history.ErrUndoConflict):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case writeContextError(w, err):
+		default:
+			http.Error(w, "failed to undo", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entry)
+}
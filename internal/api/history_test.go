@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func setupHistoryRouter() (*mux.Router, *history.Store) {
+	h := history.NewStore(store.NewMemoryStore())
+	api := &API{Store: h, History: h, AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+	return r, h
+}
+
+func TestFavoriteHistoryAndUndo(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("h1", models.Chart{AssetBase: models.AssetBase{ID: "h1", Name: "History"}, ChartType: "bar"})
+	r, _ := setupHistoryRouter()
+
+	// X-Actor is ignored unless the request carries the admin token.
+	req := httptest.NewRequest("POST", "/users/u1/favorites", bytes.NewBufferString(`{"assetId":"h1","description":"old"}`))
+	req.Header.Set("X-Actor", "mallory")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest("PATCH", "/users/u1/favorites/h1", bytes.NewBufferString(`{"description":"new"}`))
+	req.Header.Set("X-Actor", "support")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	var page history.Page
+	res := executeRequest(r, "GET", "/users/u1/favorites/history?limit=1", nil)
+	json.NewDecoder(res.Body).Decode(&page)
+	if len(page.Entries) != 1 || page.NextBefore != 2 {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if e := page.Entries[0]; e.Action != history.Edited || e.Actor != "support" || e.OldDescription != "old" || e.NewDescription != "new" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	res = executeRequest(r, "GET", "/users/u1/favorites/history?before=2", nil)
+	json.NewDecoder(res.Body).Decode(&page)
+	if len(page.Entries) != 1 || page.Entries[0].Actor != "u1" {
+		t.Errorf("actor should default to the path user, got %+v", page.Entries)
+	}
+
+	res = executeRequest(r, "POST", "/users/u1/favorites/undo", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("undo: expected 200, got %d", res.Code)
+	}
+	var favs []models.FavoriteWithAsset
+	json.NewDecoder(executeRequest(r, "GET", "/users/u1/favorites", nil).Body).Decode(&favs)
+	if len(favs) != 1 || favs[0].Description != "old" {
+		t.Errorf("undo should revert the edit, got %+v", favs)
+	}
+
+	executeRequest(r, "POST", "/users/u1/favorites/undo", nil)
+	if res := executeRequest(r, "POST", "/users/u1/favorites/undo", nil); res.Code != http.StatusNotFound {
+		t.Errorf("expected 404 with nothing left to undo, got %d", res.Code)
+	}
+
+	for _, q := range []string{"limit=0", "limit=x", "before=-1"} {
+		if res := executeRequest(r, "GET", "/users/u1/favorites/history?"+q, nil); res.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, res.Code)
+		}
+	}
+}
+
+func TestFavoriteHistoryDisabled(t *testing.T) {
+	r, _ := setupRouter()
+	if res := executeRequest(r, "GET", "/users/u1/favorites/history", nil); res.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501, got %d", res.Code)
+	}
+}
+
+func TestGraphQLMutationsAreAttributed(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("g1", models.Chart{AssetBase: models.AssetBase{ID: "g1", Name: "GraphQL"}, ChartType: "bar"})
+	r, h := setupHistoryRouter()
+
+	body := `{"query":"mutation { addFavorite(userId: \"u1\", assetId: \"g1\") { assetId } }"}`
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Set("X-Actor", "support")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	if res.Code != http.StatusOK || !bytes.Contains(res.Body.Bytes(), []byte(`"assetId":"g1"`)) {
+		t.Fatalf("unexpected response %d %s", res.Code, res.Body.String())
+	}
+	if res.Header().Get("Deprecation") != "" {
+		t.Error("/graphql should not be deprecated")
+	}
+
+	res = executeRequest(r, "POST", "/graphql", map[string]string{"query": `mutation { removeFavorite(userId: "u1", assetId: "g1") }`})
+	if !bytes.Contains(res.Body.Bytes(), []byte(`"removeFavorite":true`)) {
+		t.Fatalf("unexpected response %s", res.Body.String())
+	}
+
+	entries := h.History("u1", 0, 0).Entries
+	if len(entries) != 2 || entries[1].Actor != "support" || entries[0].Actor != "u1" {
+		t.Errorf("expected X-Actor then the user as actor, got %+v", entries)
+	}
+}
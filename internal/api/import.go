@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"my-solution/internal/export"
+
+	"github.com/gorilla/mux"
+)
+
+// maxImportBytes bounds the size of an uploaded import file.
+const maxImportBytes = 10 << 20
+
+// importFavoritesHandler adds favorites from an exported file.
+// @Summary Import favorites
+// @Description Accepts a file in the export format, either as the raw body or as the "file" field of a
+// @Description multipart form. The format comes from the format parameter, else the Content-Type or
+// @Description file extension. Asset IDs are validated against the catalog. Nothing is written on dry
+// @Description runs, malformed files or, with policy=fail, conflicts (reported with 409).
+// @Description A favorite added while the import runs stops a policy=fail import at that row with 409; the report shows which rows were written.
+// @Tags favorites
+// @Accept json
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "User ID"
+// @Param format query string false "File format" Enums(csv, json, ndjson)
+// @Param policy query string false "What to do with assets already favorited (default skip)" Enums(skip, overwrite, fail)
+// @Param dryRun query bool false "Report what would change without writing"
+// @Success 200 {object} export.Report
+// @Failure 400 {string} string "Bad request"
+// @Failure 409 {object} export.Report "Conflicts with policy=fail"
+// @Failure 413 {string} string "File too large"
+// @Failure 500 {string} string "Internal server error"
+// @Router /users/{id}/favorites/import [post]
+func (api *API) importFavoritesHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := export.ImportOptions{Policy: export.PolicySkip}
+	if v := q.Get("policy"); v != "" {
+		var err error
+		if opts.Policy, err = export.ParsePolicy(v); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := q.Get("dryRun"); v != "" {
+		var err error
+		if opts.DryRun, err = strconv.ParseBool(v); err != nil {
+			http.Error(w, "invalid dryRun parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	body, contentType := io.Reader(r.Body), r.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "multipart/form-data" {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			writeImportReadError(w, err, "multipart form must include a file field")
+			return
+		}
+		defer file.Close()
+		body, contentType = file, header.Header.Get("Content-Type")
+		if ext := strings.TrimPrefix(filepath.Ext(header.Filename), "."); q.Get("format") == "" && ext != "" {
+			q.Set("format", ext)
+		}
+	}
+
+	format, err := importFormat(q.Get("format"), contentType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := export.Import(r.Context(), api.Store, mux.Vars(r)["id"], export.NewReader(body, format), opts)
+	switch {
+	case err == nil:
+	case errors.Is(err, export.ErrConflict):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(report)
+		return
+	case errors.Is(err, export.ErrInvalidFile):
+		writeImportReadError(w, err, err.Error())
+		return
+	case writeContextError(w, err):
+		return
+	default:
+		http.Error(w, "failed to import favorites", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// importFormat picks the import file format from the format parameter,
+// falling back to the Content-Type.
+func importFormat(param, contentType string) (export.Format, error) {
+	if param != "" {
+		return export.ParseFormat(param)
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return export.CSV, nil
+	case "application/x-ndjson":
+		return export.NDJSON, nil
+	case "application/json":
+		return export.JSON, nil
+	}
+	return "", errors.New("format is required unless the Content-Type or file extension identifies it")
+}
+
+// writeImportReadError reports a failure to read the import file, using
+// 413 when the file exceeded maxImportBytes.
+func writeImportReadError(w http.ResponseWriter, err error, msg string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "import file too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, msg, http.StatusBadRequest)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/export"
+	"my-solution/internal/models"
+)
+
+func TestImportFavoritesHandler(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("imp-1", models.Chart{AssetBase: models.AssetBase{ID: "imp-1", Name: "One"}, ChartType: "bar"})
+	catalog.Global.AddAsset("imp-2", models.Chart{AssetBase: models.AssetBase{ID: "imp-2", Name: "Two"}, ChartType: "bar"})
+	r, s := setupRouter()
+	s.AddFavorite(context.Background(), "u1", "imp-1", "existing")
+
+	csvFile := "assetId,description\nimp-1,replaced\nimp-2,fresh\nnope,\n"
+	post := func(path, contentType string, body *bytes.Buffer) (*httptest.ResponseRecorder, export.Report) {
+		req := httptest.NewRequest("POST", path, body)
+		req.Header.Set("Content-Type", contentType)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		var report export.Report
+		json.NewDecoder(bytes.NewReader(res.Body.Bytes())).Decode(&report)
+		return res, report
+	}
+
+	res, report := post("/users/u1/favorites/import?policy=fail", "text/csv", bytes.NewBufferString(csvFile))
+	if res.Code != http.StatusConflict || report.Conflicts != 1 {
+		t.Fatalf("expected 409 with a conflict, got %d %+v", res.Code, report)
+	}
+
+	res, report = post("/users/u1/favorites/import?policy=overwrite&dryRun=true", "text/csv", bytes.NewBufferString(csvFile))
+	if res.Code != http.StatusOK || !report.DryRun || report.Added != 1 || report.Overwritten != 1 || report.Invalid != 1 {
+		t.Fatalf("unexpected dry run %d %+v", res.Code, report)
+	}
+	if favs, _ := s.ListFavorites(context.Background(), "u1"); len(favs) != 1 {
+		t.Fatalf("dry run should not write, got %+v", favs)
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "favorites.csv")
+	fw.Write([]byte(csvFile))
+	mw.Close()
+	res, report = post("/users/u1/favorites/import?policy=overwrite", mw.FormDataContentType(), &body)
+	if res.Code != http.StatusOK || report.Added != 1 || report.Overwritten != 1 {
+		t.Fatalf("unexpected multipart import %d %+v", res.Code, report)
+	}
+	favs, _ := s.ListFavorites(context.Background(), "u1")
+	if len(favs) != 2 || favs[0].Description != "replaced" || favs[1].Description != "fresh" {
+		t.Errorf("unexpected favorites %+v", favs)
+	}
+
+	for _, tc := range []struct{ path, contentType, body string }{
+		{"/users/u1/favorites/import", "application/octet-stream", csvFile},
+		{"/users/u1/favorites/import?policy=maybe", "text/csv", csvFile},
+		{"/users/u1/favorites/import?format=ndjson", "", "not json\n"},
+	} {
+		if res, _ := post(tc.path, tc.contentType, bytes.NewBufferString(tc.body)); res.Code != http.StatusBadRequest {
+			t.Errorf("%s %q: expected 400, got %d", tc.path, tc.body, res.Code)
+		}
+	}
+
+	big := bytes.NewBufferString("assetId,description\nimp-2," + strings.Repeat("x", maxImportBytes) + "\n")
+	if res, _ := post("/users/u1/favorites/import", "text/csv", big); res.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", res.Code)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -225,7 +226,7 @@ func TestIntegration_UserIsolation(t *testing.T) {
 	if len(listed) == 0 {
 		t.Fatalf("Expected at least one favorite for user %s immediately after addition", userA)
 	}
-	storeList, _ := s.ListFavorites(userA)
+	storeList, _ := s.ListFavorites(context.Background(), userA)
 	if len(storeList) == 0 {
 		t.Fatalf("Expected at least one favorite in store for user %s immediately after addition", userA)
 	}
@@ -251,7 +252,7 @@ func TestIntegration_UserIsolation(t *testing.T) {
 	}
 
 	// 4. Verify User A's asset is unchanged (store verification)
-	assetsA, err := s.ListFavorites(userA)
+	assetsA, err := s.ListFavorites(context.Background(), userA)
 	if err != nil {
 		t.Fatalf("list favorites: %v", err)
 	}
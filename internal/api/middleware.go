@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Per-route deadlines applied to the request context. Reads are expected
+// to be fast; writes get more headroom for slower backends.
+const (
+	readTimeout  = 5 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+// withTimeout bounds the request context of h by d. Store calls made with
+// r.Context() observe the deadline as well as client disconnects.
+func withTimeout(d time.Duration, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		defer cancel()
+		h(w, r.WithContext(ctx))
+	}
+}
+
+// writeContextError writes a response for context cancellation and deadline
+// errors and reports whether err was one of them.
+func writeContextError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		http.Error(w, "request timed out", http.StatusGatewayTimeout)
+		return true
+	case errors.Is(err, context.Canceled):
+		// The client has gone away; nobody will read the body.
+		http.Error(w, "request cancelled", statusClientClosedRequest)
+		return true
+	}
+	return false
+}
+
+// statusClientClosedRequest is the non-standard status used by nginx and
+// others when the client disconnects before a response is written.
+const statusClientClosedRequest = 499
+
+// requireAdmin rejects requests that do not carry the admin bearer token.
+// Admin endpoints are disabled entirely when no token is configured.
+func (api *API) requireAdmin(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if api.AdminToken == "" {
+			http.Error(w, "admin API disabled", http.StatusForbidden)
+			return
+		}
+		if !api.isAdmin(r.Header.Get("Authorization")) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// isAdmin reports whether authorization, the value of an Authorization
+// header, carries the admin bearer token.
+func (api *API) isAdmin(authorization string) bool {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	return ok && api.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(api.AdminToken)) == 1
+}
+
+// probeMethods are the methods methodNotAllowed tries a request with.
+var probeMethods = []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// methodNotAllowed is root's not-found and method-not-allowed handler. It
+// answers 405 with an Allow header when the path is routed for other
+// methods, and 404 otherwise. mux cannot be relied on for this once
+// subrouters are involved: a later route in the same subrouter clears the
+// method mismatch and the request ends up as 404.
+func methodNotAllowed(root *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var allowed []string
+		for _, method := range probeMethods {
+			if method == r.Method {
+				continue
+			}
+			probe := r.Clone(r.Context())
+			probe.Method = method
+			var match mux.RouteMatch
+			if root.Match(probe, &match) && match.MatchErr == nil {
+				allowed = append(allowed, method)
+			}
+		}
+		if len(allowed) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	})
+}
+
+// LegacyDeprecation is when the unversioned routes were deprecated in
+// favour of /v1.
+var LegacyDeprecation = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+// deprecated marks responses from the unversioned routes as deprecated
+// (RFC 9745), announces their sunset (RFC 8594) if one is configured and
+// links to the /v1 route that replaces them.
+func (api *API) deprecated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := w.Header()
+		h.Set("Deprecation", fmt.Sprintf("@%d", LegacyDeprecation.Unix()))
+		if !api.Sunset.IsZero() {
+			h.Set("Sunset", api.Sunset.UTC().Format(http.TimeFormat))
+		}
+		h.Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, "/v1"+r.URL.EscapedPath()))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// blockingStore waits for the request context to end on every call.
+type blockingStore struct {
+	store.Store
+}
+
+func (blockingStore) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestWithTimeoutReturnsGatewayTimeout(t *testing.T) {
+	api := &API{Store: blockingStore{}}
+	r := mux.NewRouter()
+	r.HandleFunc("/users/{id}/favorites", withTimeout(10*time.Millisecond, api.listFavoritesHandler))
+
+	req := httptest.NewRequest("GET", "/users/slow/favorites", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusGatewayTimeout {
+		t.Errorf("expected 504, got %d", res.Code)
+	}
+}
+
+func TestCancelledRequestIsNotServed(t *testing.T) {
+	api := &API{Store: blockingStore{}}
+	r := mux.NewRouter()
+	r.HandleFunc("/users/{id}/favorites", withTimeout(time.Minute, api.listFavoritesHandler))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("GET", "/users/gone/favorites", nil).WithContext(ctx)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != statusClientClosedRequest {
+		t.Errorf("expected %d, got %d", statusClientClosedRequest, res.Code)
+	}
+}
+
+func TestVersionedAndLegacyRoutes(t *testing.T) {
+	sunset := time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+	api := &API{Store: store.NewMemoryStore(), Sunset: sunset}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	res := executeRequest(r, "GET", "/v1/users/u1/favorites", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("/v1: expected 200, got %d", res.Code)
+	}
+	if res.Header().Get("Deprecation") != "" {
+		t.Error("/v1 routes must not be deprecated")
+	}
+
+	res = executeRequest(r, "GET", "/users/u1/favorites", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("legacy: expected 200, got %d", res.Code)
+	}
+	if got, want := res.Header().Get("Deprecation"), fmt.Sprintf("@%d", LegacyDeprecation.Unix()); got != want {
+		t.Errorf("Deprecation: expected %q, got %q", want, got)
+	}
+	if got := res.Header().Get("Sunset"); got != "Thu, 01 Apr 2027 00:00:00 GMT" {
+		t.Errorf("unexpected Sunset %q", got)
+	}
+	if got := res.Header().Get("Link"); got != `</v1/users/u1/favorites>; rel="successor-version"` {
+		t.Errorf("unexpected Link %q", got)
+	}
+
+	if res := executeRequest(r, "GET", "/healthz", nil); res.Code != http.StatusOK || res.Header().Get("Deprecation") != "" {
+		t.Errorf("/healthz should stay undeprecated at the root, got %d %v", res.Code, res.Header())
+	}
+	if res := executeRequest(r, "GET", "/v1/healthz", nil); res.Code != http.StatusNotFound {
+		t.Errorf("/healthz should only be served at the root, got %d", res.Code)
+	}
+
+	for _, path := range []string{"/v1/assets", "/assets"} {
+		res := executeRequest(r, "POST", path, nil)
+		if res.Code != http.StatusMethodNotAllowed || res.Header().Get("Allow") != "GET" {
+			t.Errorf("POST %s: expected 405 allowing GET, got %d %q", path, res.Code, res.Header().Get("Allow"))
+		}
+	}
+	if res := executeRequest(r, "GET", "/v1/nope", nil); res.Code != http.StatusNotFound {
+		t.Errorf("unknown /v1 route: expected 404, got %d", res.Code)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"my-solution/internal/orphans"
+)
+
+// orphansEnabled writes 501 and returns false when the sweeper is not configured.
+func (api *API) orphansEnabled(w http.ResponseWriter) bool {
+	if api.Orphans == nil {
+		http.Error(w, "orphan detection not enabled", http.StatusNotImplemented)
+		return false
+	}
+	return true
+}
+
+func writeOrphanError(w http.ResponseWriter, err error) {
+	if errors.Is(err, orphans.ErrUnsupported) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	if writeContextError(w, err) {
+		return
+	}
+	http.Error(w, "orphan detection failed", http.StatusInternalServerError)
+}
+
+// orphanReportHandler reports favorites whose asset is missing from the catalog.
+// @Summary Orphaned favorites report
+// @Description Per missing asset: how many users still favorite it and when it will be archived
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} orphans.Report
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 501 {string} string "Not enabled"
+// @Router /admin/orphans [get]
+func (api *API) orphanReportHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.orphansEnabled(w) {
+		return
+	}
+	report, err := api.Orphans.Report(r.Context())
+	if err != nil {
+		writeOrphanError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// orphanArchiveHandler lists archived orphaned favorites awaiting purge.
+// @Summary Archived orphaned favorites
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} orphans.ArchivedFavorite
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 501 {string} string "Not enabled"
+// @Router /admin/orphans/archive [get]
+func (api *API) orphanArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.orphansEnabled(w) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.Orphans.Archived())
+}
+
+// orphanSweepHandler runs an orphan sweep immediately.
+// @Summary Run orphan sweep
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} orphans.SweepResult
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 501 {string} string "Not enabled"
+// @Router /admin/orphans/sweep [post]
+func (api *API) orphanSweepHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.orphansEnabled(w) {
+		return
+	}
+	res, err := api.Orphans.Sweep(r.Context())
+	if err != nil {
+		writeOrphanError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/orphans"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestListFavoritesIncludeUnavailable(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("here", models.Chart{AssetBase: models.AssetBase{ID: "here", Name: "Here"}, ChartType: "bar"})
+	r, s := setupRouter()
+	s.AddFavorite(context.Background(), "u1", "here", "")
+	s.AddFavorite(context.Background(), "u1", "gone", "kept note")
+
+	var favs []map[string]interface{}
+	res := executeRequest(r, "GET", "/users/u1/favorites", nil)
+	json.NewDecoder(res.Body).Decode(&favs)
+	if len(favs) != 1 {
+		t.Fatalf("default listing should skip orphans, got %v", favs)
+	}
+	if _, ok := favs[0]["status"]; ok {
+		t.Errorf("available favorites should carry no status: %v", favs[0])
+	}
+
+	res = executeRequest(r, "GET", "/users/u1/favorites?includeUnavailable=true", nil)
+	json.NewDecoder(res.Body).Decode(&favs)
+	if len(favs) != 2 || favs[1]["status"] != "unavailable" || favs[1]["asset"] != nil || favs[1]["description"] != "kept note" {
+		t.Fatalf("unexpected listing %v", favs)
+	}
+
+	if res := executeRequest(r, "GET", "/users/u1/favorites?includeUnavailable=maybe", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", res.Code)
+	}
+}
+
+func TestOrphanAdminEndpoints(t *testing.T) {
+	catalog.Initialize()
+	s := store.NewMemoryStore()
+	s.AddFavorite(context.Background(), "u1", "gone", "")
+
+	sweeper := orphans.NewSweeper(s, 0, time.Hour)
+	api := &API{Store: s, Orphans: sweeper, AdminToken: testAdminToken}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/orphans", nil))
+	var report []orphans.Report
+	json.NewDecoder(res.Body).Decode(&report)
+	if res.Code != http.StatusOK || len(report) != 1 || report[0].FavoriteCount != 1 {
+		t.Fatalf("unexpected report %d %+v", res.Code, report)
+	}
+
+	// With no grace period the second sweep archives.
+	for i := 0; i < 2; i++ {
+		res = httptest.NewRecorder()
+		r.ServeHTTP(res, adminRequest("POST", "/admin/orphans/sweep", nil))
+		if res.Code != http.StatusOK {
+			t.Fatalf("sweep: %d", res.Code)
+		}
+	}
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/orphans/archive", nil))
+	var archived []orphans.ArchivedFavorite
+	json.NewDecoder(res.Body).Decode(&archived)
+	if len(archived) != 1 || archived[0].UserID != "u1" {
+		t.Errorf("unexpected archive %+v", archived)
+	}
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+// Defaults for the popularity endpoints.
+const (
+	defaultTopN             = 10
+	maxTopN                 = 100
+	defaultTrendingWindow   = 7 * 24 * time.Hour
+	defaultTrendingHalfLife = 24 * time.Hour
+)
+
+// PopularAsset is an asset with its popularity score.
+type PopularAsset struct {
+	Asset         models.Asset `json:"asset"`
+	Type          string       `json:"type"`
+	FavoriteCount int          `json:"favoriteCount"`
+	Score         float64      `json:"score,omitempty"` // Time-decayed score (trending only)
+}
+
+// catalogEntry flattens an asset's JSON and adds fields such as its
+// favorite count, so /assets items keep their shape with a few extras.
+type catalogEntry struct {
+	asset models.Asset
+	extra map[string]any
+}
+
+func (a catalogEntry) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(a.asset)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for k, v := range a.extra {
+		if fields[k], err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// popularityQuery holds the common limit and type parameters.
+type popularityQuery struct {
+	limit     int
+	assetType string
+}
+
+func parsePopularityQuery(w http.ResponseWriter, r *http.Request) (popularityQuery, bool) {
+	q := popularityQuery{limit: defaultTopN, assetType: r.URL.Query().Get("type")}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxTopN {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return q, false
+		}
+		q.limit = n
+	}
+	if q.assetType != "" && !models.ValidAssetType(q.assetType) {
+		http.Error(w, "type must be one of chart, insight, audience", http.StatusBadRequest)
+		return q, false
+	}
+	return q, true
+}
+
+// popularityIndex returns the store's popularity index, writing 501 if the
+// backend does not maintain one.
+func (api *API) popularityIndex(w http.ResponseWriter) (store.PopularityIndex, bool) {
+	idx, ok := store.Find[store.PopularityIndex](api.Store)
+	if !ok {
+		http.Error(w, "popularity index not supported by store", http.StatusNotImplemented)
+	}
+	return idx, ok
+}
+
+// rankAssets joins scores with the catalog, filters by type and returns
+// the top q.limit assets by score, breaking ties by count then asset ID.
+func rankAssets(scores map[string]float64, counts map[string]int, q popularityQuery) []PopularAsset {
+	result := make([]PopularAsset, 0, len(scores))
+	for assetID, score := range scores {
+		asset, ok := catalog.Global.Get(assetID)
+		if !ok {
+			continue
+		}
+		typ := models.AssetType(asset)
+		if q.assetType != "" && typ != q.assetType {
+			continue
+		}
+		result = append(result, PopularAsset{Asset: asset, Type: typ, FavoriteCount: counts[assetID], Score: score})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.FavoriteCount != b.FavoriteCount {
+			return a.FavoriteCount > b.FavoriteCount
+		}
+		return a.Asset.GetID() < b.Asset.GetID()
+	})
+	if len(result) > q.limit {
+		result = result[:q.limit]
+	}
+	return result
+}
+
+// popularAssetsHandler returns the most favorited assets.
+// @Summary Most favorited assets
+// @Description Top-N assets by number of users who favorited them, optionally for one asset type
+// @Tags assets
+// @Produce json
+// @Param limit query int false "Number of assets (1-100, default 10)"
+// @Param type query string false "Asset type" Enums(chart, insight, audience)
+// @Success 200 {array} api.PopularAsset
+// @Failure 400 {string} string "Bad request"
+// @Failure 501 {string} string "Not supported by store"
+// @Router /assets/popular [get]
+func (api *API) popularAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	q, ok := parsePopularityQuery(w, r)
+	if !ok {
+		return
+	}
+	idx, ok := api.popularityIndex(w)
+	if !ok {
+		return
+	}
+
+	counts, err := idx.FavoriteCounts(r.Context())
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to load popularity", http.StatusInternalServerError)
+		return
+	}
+
+	scores := make(map[string]float64, len(counts))
+	for assetID, n := range counts {
+		scores[assetID] = float64(n)
+	}
+	result := rankAssets(scores, counts, q)
+	for i := range result {
+		result[i].Score = 0 // Score equals the count here; omit it
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// trendingAssetsHandler returns assets with the most recent favorite activity.
+// @Summary Trending assets
+// @Description Assets ranked by time-decayed favorite counts: each favorite created within the window
+// @Description contributes 0.5^(age/halfLife) to its asset's score.
+// @Tags assets
+// @Produce json
+// @Param limit query int false "Number of assets (1-100, default 10)"
+// @Param type query string false "Asset type" Enums(chart, insight, audience)
+// @Param window query string false "How far back to look, as a Go duration (default 168h)"
+// @Param halfLife query string false "Decay half-life, as a Go duration (default 24h)"
+// @Success 200 {array} api.PopularAsset
+// @Failure 400 {string} string "Bad request"
+// @Failure 501 {string} string "Not supported by store"
+// @Router /assets/trending [get]
+func (api *API) trendingAssetsHandler(w http.ResponseWriter, r *http.Request) {
+	q, ok := parsePopularityQuery(w, r)
+	if !ok {
+		return
+	}
+	window, ok := parsePositiveDuration(w, r, "window", defaultTrendingWindow)
+	if !ok {
+		return
+	}
+	halfLife, ok := parsePositiveDuration(w, r, "halfLife", defaultTrendingHalfLife)
+	if !ok {
+		return
+	}
+	idx, ok := api.popularityIndex(w)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	times, err := idx.FavoriteTimes(r.Context(), now.Add(-window))
+	if err != nil {
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to load popularity", http.StatusInternalServerError)
+		return
+	}
+
+	scores := make(map[string]float64, len(times))
+	counts := make(map[string]int, len(times))
+	for assetID, ts := range times {
+		counts[assetID] = len(ts)
+		for _, t := range ts {
+			age := now.Sub(t)
+			scores[assetID] += math.Exp2(-float64(age) / float64(halfLife))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rankAssets(scores, counts, q))
+}
+
+func parsePositiveDuration(w http.ResponseWriter, r *http.Request, name string, def time.Duration) (time.Duration, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, true
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		http.Error(w, "invalid "+name+" duration", http.StatusBadRequest)
+		return 0, false
+	}
+	return d, true
+}
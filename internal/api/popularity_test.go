@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+)
+
+func seedPopularityCatalog() {
+	catalog.Initialize()
+	catalog.Global.AddAsset("pop-chart", models.Chart{AssetBase: models.AssetBase{ID: "pop-chart", Name: "C"}, ChartType: "bar"})
+	catalog.Global.AddAsset("pop-insight", models.Insight{AssetBase: models.AssetBase{ID: "pop-insight", Name: "I"}, Metric: "m", Value: "v"})
+	catalog.Global.AddAsset("pop-audience", models.Audience{AssetBase: models.AssetBase{ID: "pop-audience", Name: "A"}, Segment: "s"})
+}
+
+func TestPopularAssets(t *testing.T) {
+	seedPopularityCatalog()
+	r, _ := setupRouter()
+
+	for _, u := range []string{"u1", "u2", "u3"} {
+		executeRequest(r, "POST", "/users/"+u+"/favorites", map[string]string{"assetId": "pop-insight"})
+	}
+	for _, u := range []string{"u1", "u2"} {
+		executeRequest(r, "POST", "/users/"+u+"/favorites", map[string]string{"assetId": "pop-chart"})
+	}
+
+	res := executeRequest(r, "GET", "/assets/popular", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var popular []struct {
+		Type          string `json:"type"`
+		FavoriteCount int    `json:"favoriteCount"`
+	}
+	json.NewDecoder(res.Body).Decode(&popular)
+	if len(popular) != 2 || popular[0].Type != "insight" || popular[0].FavoriteCount != 3 || popular[1].FavoriteCount != 2 {
+		t.Errorf("unexpected popular list %+v", popular)
+	}
+
+	res = executeRequest(r, "GET", "/assets/popular?type=chart&limit=5", nil)
+	json.NewDecoder(res.Body).Decode(&popular)
+	if len(popular) != 1 || popular[0].Type != "chart" {
+		t.Errorf("unexpected chart list %+v", popular)
+	}
+
+	for _, q := range []string{"?limit=0", "?limit=abc", "?type=alien"} {
+		if res := executeRequest(r, "GET", "/assets/popular"+q, nil); res.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, res.Code)
+		}
+	}
+}
+
+func TestTrendingAssets(t *testing.T) {
+	seedPopularityCatalog()
+	r, _ := setupRouter()
+
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "pop-audience"})
+	executeRequest(r, "POST", "/users/u2/favorites", map[string]string{"assetId": "pop-audience"})
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "pop-chart"})
+
+	res := executeRequest(r, "GET", "/assets/trending?halfLife=1h", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var raw []map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&raw)
+	if len(raw) != 2 || raw[0]["type"] != "audience" {
+		t.Fatalf("unexpected trending list %v", raw)
+	}
+	if score := raw[0]["score"].(float64); score <= 1.9 || score > 2 {
+		t.Errorf("expected score close to 2 for two fresh favorites, got %v", score)
+	}
+
+	if res := executeRequest(r, "GET", "/assets/trending?window=-1h", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for negative window, got %d", res.Code)
+	}
+}
+
+func TestListAssetsIncludesFavoriteCount(t *testing.T) {
+	seedPopularityCatalog()
+	r, _ := setupRouter()
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "pop-chart"})
+
+	res := executeRequest(r, "GET", "/assets", nil)
+	var assets []map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&assets)
+	if len(assets) != 3 {
+		t.Fatalf("expected 3 assets, got %d", len(assets))
+	}
+	for _, a := range assets {
+		want := 0.0
+		if a["ID"] == "pop-chart" {
+			want = 1
+		}
+		if a["favoriteCount"] != want {
+			t.Errorf("%v: expected favoriteCount %v, got %v", a["ID"], want, a["favoriteCount"])
+		}
+		if a["Name"] == nil {
+			t.Errorf("asset fields not flattened: %v", a)
+		}
+	}
+}
+
+func TestListAssetsForUser(t *testing.T) {
+	seedPopularityCatalog()
+	r, _ := setupRouter()
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "pop-chart", "description": "mine"})
+
+	res := executeRequest(r, "GET", "/assets?forUser=u1", nil)
+	var assets []map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&assets)
+	if len(assets) != 3 {
+		t.Fatalf("expected 3 assets, got %d", len(assets))
+	}
+	for _, a := range assets {
+		favorite := a["ID"] == "pop-chart"
+		if a["isFavorite"] != favorite {
+			t.Errorf("%v: expected isFavorite %v, got %v", a["ID"], favorite, a["isFavorite"])
+		}
+		if favorite && a["favoriteDescription"] != "mine" {
+			t.Errorf("expected the user's description, got %v", a["favoriteDescription"])
+		}
+		if !favorite && a["favoriteDescription"] != nil {
+			t.Errorf("%v: unexpected description %v", a["ID"], a["favoriteDescription"])
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/recommend"
+
+	"github.com/gorilla/mux"
+)
+
+// Recommendation is a suggested asset with its similarity score.
+type Recommendation struct {
+	Asset models.Asset `json:"asset"`
+	Type  string       `json:"type"`
+	Score float64      `json:"score"`
+}
+
+// recommendationsHandler suggests assets based on co-favorites.
+// @Summary Recommended assets for a user
+// @Description Catalog assets the user has not favorited, ranked by similarity to the assets
+// @Description they have ("users who favorited this also favorited"). Users without favorites get an empty list.
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Param limit query int false "Number of assets (1-100, default 10)"
+// @Param type query string false "Asset type" Enums(chart, insight, audience)
+// @Param metric query string false "Similarity metric (default jaccard)" Enums(jaccard, cosine)
+// @Success 200 {array} api.Recommendation
+// @Failure 400 {string} string "Bad request"
+// @Failure 501 {string} string "Recommendations not enabled"
+// @Router /users/{id}/recommendations [get]
+func (api *API) recommendationsHandler(w http.ResponseWriter, r *http.Request) {
+	if api.Recommender == nil {
+		http.Error(w, "recommendations not enabled", http.StatusNotImplemented)
+		return
+	}
+	q, ok := parsePopularityQuery(w, r)
+	if !ok {
+		return
+	}
+	metric := recommend.Metric(r.URL.Query().Get("metric"))
+	switch metric {
+	case "":
+		metric = recommend.Jaccard
+	case recommend.Jaccard, recommend.Cosine:
+	default:
+		http.Error(w, "metric must be jaccard or cosine", http.StatusBadRequest)
+		return
+	}
+
+	userID := mux.Vars(r)["id"]
+	assets := make(map[string]models.Asset)
+	keep := func(assetID string) bool {
+		asset, ok := catalog.Global.Get(assetID)
+		if !ok || (q.assetType != "" && models.AssetType(asset) != q.assetType) {
+			return false
+		}
+		assets[assetID] = asset
+		return true
+	}
+
+	scored := api.Recommender.Recommend(userID, metric, q.limit, keep)
+	result := make([]Recommendation, len(scored))
+	for i, s := range scored {
+		asset := assets[s.AssetID]
+		result[i] = Recommendation{Asset: asset, Type: models.AssetType(asset), Score: s.Score}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"my-solution/internal/events"
+	"my-solution/internal/recommend"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRecommendations(t *testing.T) {
+	seedPopularityCatalog()
+
+	bus := events.NewBus(0)
+	engine := recommend.NewEngine()
+	bus.AddListener(engine.Observe)
+	api := &API{Store: events.NewStore(store.NewMemoryStore(), bus), Events: bus, Recommender: engine}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	add := func(user, asset string) {
+		if res := executeRequest(r, "POST", "/users/"+user+"/favorites", map[string]string{"assetId": asset}); res.Code != http.StatusCreated {
+			t.Fatalf("add %s/%s: %d", user, asset, res.Code)
+		}
+	}
+	add("u1", "pop-chart")
+	add("u1", "pop-insight")
+	add("u2", "pop-chart")
+	add("u2", "pop-insight")
+	add("u3", "pop-chart")
+	add("u3", "pop-audience")
+	add("me", "pop-chart")
+
+	res := executeRequest(r, "GET", "/users/me/recommendations", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.Code)
+	}
+	var recs []map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&recs)
+	if len(recs) != 2 || recs[0]["type"] != "insight" || recs[1]["type"] != "audience" {
+		t.Fatalf("unexpected recommendations %v", recs)
+	}
+
+	res = executeRequest(r, "GET", "/users/me/recommendations?type=audience&metric=cosine", nil)
+	json.NewDecoder(res.Body).Decode(&recs)
+	if len(recs) != 1 || recs[0]["type"] != "audience" {
+		t.Errorf("type filter not applied: %v", recs)
+	}
+
+	// Removing a favorite updates recommendations immediately.
+	executeRequest(r, "DELETE", "/users/u3/favorites/pop-audience", nil)
+	res = executeRequest(r, "GET", "/users/me/recommendations", nil)
+	json.NewDecoder(res.Body).Decode(&recs)
+	if len(recs) != 1 || recs[0]["type"] != "insight" {
+		t.Errorf("expected only insight after removal, got %v", recs)
+	}
+
+	if res := executeRequest(r, "GET", "/users/me/recommendations?metric=pearson", nil); res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for unknown metric, got %d", res.Code)
+	}
+}
+
+func TestRecommendationsDisabled(t *testing.T) {
+	r, _ := setupRouter()
+	if res := executeRequest(r, "GET", "/users/me/recommendations", nil); res.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501, got %d", res.Code)
+	}
+}
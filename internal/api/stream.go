@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"my-solution/internal/events"
+
+	"github.com/gorilla/mux"
+)
+
+// sseHeartbeat is how often a comment line is sent on idle streams so
+// that proxies do not close the connection.
+var sseHeartbeat = 15 * time.Second
+
+// favoriteEventsHandler streams a user's favorite changes as Server-Sent Events.
+// @Summary Stream favorite changes
+// @Description Server-Sent Events feed of favorite.added, favorite.removed and favorite.description_edited events.
+// @Description Each event id is the per-user sequence number; reconnect with Last-Event-ID to resume.
+// @Description A "reset" event means some events were lost and the client should re-fetch its favorites.
+// @Tags favorites
+// @Param id path string true "User ID"
+// @Param Last-Event-ID header string false "Resume after this sequence number"
+// @Produce text/event-stream
+// @Success 200 {object} events.Event
+// @Failure 400 {string} string "Bad request"
+// @Failure 501 {string} string "Event stream not enabled"
+// @Router /users/{id}/favorites/events [get]
+func (api *API) favoriteEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if api.Events == nil {
+		http.Error(w, "event stream not enabled", http.StatusNotImplemented)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	userID := mux.Vars(r)["id"]
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("lastEventId")
+	}
+	var after uint64
+	if lastID != "" {
+		var err error
+		if after, err = strconv.ParseUint(lastID, 10, 64); err != nil {
+			http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sub, backlog, complete := api.Events.Subscribe(userID, after)
+	defer sub.Cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if lastID != "" && !complete {
+		fmt.Fprintf(w, "event: reset\ndata: {\"lastSeq\":%d}\n\n", api.Events.LastSeq(userID))
+	}
+	for _, e := range backlog {
+		writeSSE(w, e)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case e, ok := <-sub.Events:
+			if !ok {
+				// Dropped for being too slow; the client reconnects with
+				// Last-Event-ID and catches up from the replay buffer.
+				return
+			}
+			writeSSE(w, e)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, e events.Event) {
+	data, _ := json.Marshal(e)
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Seq, e.Type, data)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func setupEventsServer(t *testing.T) (*httptest.Server, *events.Bus) {
+	t.Helper()
+	catalog.Initialize()
+	catalog.Global.AddAsset("sse-chart", models.Chart{
+		AssetBase: models.AssetBase{ID: "sse-chart", Name: "Chart"},
+		ChartType: "bar",
+	})
+
+	bus := events.NewBus(0)
+	api := &API{Store: events.NewStore(store.NewMemoryStore(), bus), Events: bus}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv, bus
+}
+
+// readSSE reads one event block from the stream, skipping comments.
+func readSSE(t *testing.T, br *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if len(fields) > 0 {
+				return fields
+			}
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		k, v, _ := strings.Cut(line, ": ")
+		fields[k] = v
+	}
+}
+
+func TestFavoriteEventsStream(t *testing.T) {
+	srv, _ := setupEventsServer(t)
+
+	res, err := http.Get(srv.URL + "/users/u1/favorites/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	body, _ := json.Marshal(AddFavoriteRequest{AssetID: "sse-chart", Description: "hi"})
+	post, err := http.Post(srv.URL+"/users/u1/favorites", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	post.Body.Close()
+
+	ev := readSSE(t, bufio.NewReader(res.Body))
+	if ev["id"] != "1" || ev["event"] != string(events.FavoriteAdded) {
+		t.Fatalf("unexpected event %v", ev)
+	}
+	var e events.Event
+	if err := json.Unmarshal([]byte(ev["data"]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.AssetID != "sse-chart" || e.Description != "hi" {
+		t.Errorf("unexpected payload %+v", e)
+	}
+}
+
+func TestFavoriteEventsResume(t *testing.T) {
+	srv, bus := setupEventsServer(t)
+	bus.Publish(events.Event{Type: events.FavoriteAdded, UserID: "u1", AssetID: "a"})
+	bus.Publish(events.Event{Type: events.FavoriteRemoved, UserID: "u1", AssetID: "a"})
+
+	req, _ := http.NewRequest("GET", srv.URL+"/users/u1/favorites/events", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	ev := readSSE(t, bufio.NewReader(res.Body))
+	if ev["id"] != "2" || ev["event"] != string(events.FavoriteRemoved) {
+		t.Errorf("expected replay of event 2, got %v", ev)
+	}
+}
+
+func TestFavoriteEventsInvalidLastEventID(t *testing.T) {
+	srv, _ := setupEventsServer(t)
+
+	req, _ := http.NewRequest("GET", srv.URL+"/users/u1/favorites/events", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", res.StatusCode)
+	}
+}
+
+func TestFavoriteEventsDisabled(t *testing.T) {
+	r, _ := setupRouter()
+	req := httptest.NewRequest("GET", "/users/u1/favorites/events", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	if res.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501, got %d", res.Code)
+	}
+}
+
+func TestNoOpDeletePublishesNothing(t *testing.T) {
+	srv, bus := setupEventsServer(t)
+
+	for _, path := range []string{"/users/u1/favorites/sse-chart", "/users/u1/favorites/sse-chart?strict=true"} {
+		req, _ := http.NewRequest(http.MethodDelete, srv.URL+path, nil)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	if seq := bus.LastSeq("u1"); seq != 0 {
+		t.Errorf("expected no events for removals that did nothing, last seq %d", seq)
+	}
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/store"
+	"my-solution/internal/ws"
+
+	"github.com/gorilla/mux"
+)
+
+// WebSocket session tuning. Variables so tests can shorten them.
+var (
+	wsWriteWait    = 10 * time.Second // Deadline for a single frame write
+	wsPongWait     = 60 * time.Second // Close the session if nothing is read for this long
+	wsPingInterval = 30 * time.Second // Must be shorter than wsPongWait
+)
+
+// wsSendQueue bounds the outgoing messages buffered per session. When
+// pushed events would overflow it, the session is closed with 1013 so the
+// client reconnects and re-syncs instead of stalling the server.
+const wsSendQueue = 64
+
+// SyncCommand is a client-to-server message on the favorites WebSocket.
+type SyncCommand struct {
+	ID          string `json:"id"` // Echoed back in the ack
+	Op          string `json:"op"` // "add", "remove" or "edit"
+	AssetID     string `json:"assetId"`
+	Description string `json:"description"`
+}
+
+// SyncMessage is a server-to-client message on the favorites WebSocket.
+// Type is "ack" for command results, "event" for changes pushed from
+// other sessions and "reset" when events after the client's lastEventId
+// are no longer available and it must reload its favorites.
+type SyncMessage struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id,omitempty"`
+	Status  int           `json:"status,omitempty"` // HTTP-equivalent status of the command
+	Error   string        `json:"error,omitempty"`
+	Event   *events.Event `json:"event,omitempty"`
+	LastSeq uint64        `json:"lastSeq,omitempty"` // Latest sequence number, for "reset"
+}
+
+// favoritesSyncHandler upgrades to a WebSocket for bidirectional favorites sync.
+// @Summary Favorites sync over WebSocket
+// @Description Upgrades to a WebSocket. Clients send api.SyncCommand messages (add, remove, edit)
+// @Description and receive api.SyncMessage acks, plus "event" messages for changes made by other sessions.
+// @Description Events buffered after lastEventId (default 0) are pushed first.
+// @Tags favorites
+// @Param id path string true "User ID"
+// @Param lastEventId query integer false "Sequence number of the last event the client has seen"
+// @Success 101 {object} api.SyncMessage
+// @Failure 400 {string} string "Invalid lastEventId"
+// @Failure 426 {string} string "Upgrade required"
+// @Router /users/{id}/favorites/ws [get]
+func (api *API) favoritesSyncHandler(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+
+	var after uint64
+	if v := r.URL.Query().Get("lastEventId"); v != "" {
+		var err error
+		if after, err = strconv.ParseUint(v, 10, 64); err != nil {
+			http.Error(w, "invalid lastEventId", http.StatusBadRequest)
+			return
+		}
+	}
+
+	conn, err := ws.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+
+	s := &syncSession{
+		api:    api,
+		conn:   conn,
+		userID: userID,
+		origin: newSessionID(),
+		send:   make(chan []byte, wsSendQueue),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	s.run(r.Context(), after, r.URL.Query().Has("lastEventId"))
+}
+
+type syncSession struct {
+	api    *API
+	conn   *ws.Conn
+	userID string
+	origin string
+	send   chan []byte
+	stop   chan struct{} // Closed when the client stops sending
+	done   chan struct{} // Closed when the session is closed
+
+	closeOnce sync.Once
+}
+
+func newSessionID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// run serves the session until the client stops sending, after
+// subscribing from after and pushing the events buffered since then. If
+// resume is set and some of those events are gone, the client is told to
+// reset instead.
+func (s *syncSession) run(ctx context.Context, after uint64, resume bool) {
+	defer s.conn.Close()
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		s.writeLoop()
+	}()
+
+	if s.api.Events != nil {
+		// One Subscribe call, so nothing published while we connect is
+		// missed between the backlog and the live feed.
+		sub, backlog, complete := s.api.Events.Subscribe(s.userID, after)
+		defer sub.Cancel()
+		if resume && !complete {
+			s.enqueue(SyncMessage{Type: "reset", LastSeq: s.api.Events.LastSeq(s.userID)}, true)
+		}
+		for _, e := range backlog {
+			s.enqueue(SyncMessage{Type: "event", Event: &e}, true)
+		}
+		go s.pushLoop(sub)
+	}
+
+	s.readLoop(ctx)
+	// Let the writer send the acks already queued before it closes.
+	close(s.stop)
+	<-written
+}
+
+// close stops the session once, sending a close frame with code.
+func (s *syncSession) close(code int, reason string) {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.conn.WriteClose(code, reason)
+		s.conn.Close()
+	})
+}
+
+func (s *syncSession) readLoop(ctx context.Context) {
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.PongHandler = func([]byte) {
+		s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	}
+
+	for {
+		op, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		if op != ws.OpText {
+			s.close(ws.CloseUnsupportedData, "text messages only")
+			return
+		}
+
+		var cmd SyncCommand
+		ack := SyncMessage{Type: "ack"}
+		if err := json.Unmarshal(data, &cmd); err != nil {
+			ack.Status, ack.Error = http.StatusBadRequest, "invalid command"
+		} else {
+			ack.ID = cmd.ID
+			ack.Status, ack.Error = s.apply(ctx, cmd)
+		}
+
+		// Blocking here stops us reading further commands until the
+		// client drains its acks, which is the backpressure we want.
+		if !s.enqueue(ack, true) {
+			return
+		}
+	}
+}
+
+// apply runs cmd against the same store methods as the REST handlers and
+// returns the status the equivalent REST call would have produced.
+func (s *syncSession) apply(ctx context.Context, cmd SyncCommand) (int, string) {
+	if cmd.AssetID == "" {
+		return http.StatusBadRequest, "assetId is required"
+	}
+
+	ctx, cancel := context.WithTimeout(events.WithOrigin(ctx, s.origin), writeTimeout)
+	defer cancel()
+
+	var (
+		err    error
+		status = http.StatusNoContent
+	)
+	switch cmd.Op {
+	case "add":
+		assetID, ok := catalog.Global.Resolve(cmd.AssetID)
+		if !ok {
+			return http.StatusNotFound, "asset not found in catalog"
+		}
+		err = s.api.Store.AddFavorite(ctx, s.userID, assetID, cmd.Description)
+		status = http.StatusCreated
+	case "remove":
+		_, err = s.api.Store.RemoveFavorite(ctx, s.userID, cmd.AssetID)
+	case "edit":
+		err = s.api.Store.EditFavoriteDescription(ctx, s.userID, cmd.AssetID, cmd.Description)
+	default:
+		return http.StatusBadRequest, "unknown op"
+	}
+
+	switch {
+	case err == nil:
+		return status, ""
+	case errors.Is(err, store.ErrAlreadyFavorited):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, store.ErrAssetNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout, "request timed out"
+	default:
+		return http.StatusInternalServerError, "internal error"
+	}
+}
+
+// pushLoop forwards events from other sessions to the client.
+func (s *syncSession) pushLoop(sub *events.Subscription) {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.stop:
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				s.close(ws.CloseTryAgainLater, "event feed lagged")
+				return
+			}
+			if e.Origin == s.origin {
+				continue
+			}
+			if !s.enqueue(SyncMessage{Type: "event", Event: &e}, false) {
+				s.close(ws.CloseTryAgainLater, "consumer too slow")
+				return
+			}
+		}
+	}
+}
+
+// enqueue queues msg for the writer. If block is false and the queue is
+// full it reports false instead of waiting.
+func (s *syncSession) enqueue(msg SyncMessage, block bool) bool {
+	data, _ := json.Marshal(msg)
+	if !block {
+		select {
+		case s.send <- data:
+			return true
+		case <-s.done:
+			return false
+		default:
+			return false
+		}
+	}
+	select {
+	case s.send <- data:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *syncSession) writeLoop() {
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case data := <-s.send:
+			if err := s.conn.WriteMessage(ws.OpText, data, time.Now().Add(wsWriteWait)); err != nil {
+				s.close(ws.CloseInternalError, "")
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WritePing(nil, time.Now().Add(wsWriteWait)); err != nil {
+				s.close(ws.CloseInternalError, "")
+				return
+			}
+		case <-s.stop:
+			s.drain()
+			return
+		}
+	}
+}
+
+// drain writes the messages still queued and then closes the session
+// normally.
+func (s *syncSession) drain() {
+	for {
+		select {
+		case data := <-s.send:
+			if err := s.conn.WriteMessage(ws.OpText, data, time.Now().Add(wsWriteWait)); err != nil {
+				s.close(ws.CloseInternalError, "")
+				return
+			}
+		default:
+			s.close(ws.CloseNormal, "")
+			return
+		}
+	}
+}
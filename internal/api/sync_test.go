@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"my-solution/internal/events"
+	"my-solution/internal/ws"
+)
+
+func dialSync(t *testing.T, baseURL, userID string) *ws.Conn {
+	t.Helper()
+	return dialSyncQuery(t, baseURL, userID, "")
+}
+
+func dialSyncQuery(t *testing.T, baseURL, userID, query string) *ws.Conn {
+	t.Helper()
+	c, err := ws.Dial("ws"+strings.TrimPrefix(baseURL, "http")+"/users/"+userID+"/favorites/ws"+query, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func sendCommand(t *testing.T, c *ws.Conn, cmd SyncCommand) {
+	t.Helper()
+	data, _ := json.Marshal(cmd)
+	if err := c.WriteMessage(ws.OpText, data, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+func readMessage(t *testing.T, c *ws.Conn) SyncMessage {
+	t.Helper()
+	c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var msg SyncMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	return msg
+}
+
+func TestFavoritesSync_CommandsAndPush(t *testing.T) {
+	srv, _ := setupEventsServer(t)
+	a := dialSync(t, srv.URL, "u1")
+	b := dialSync(t, srv.URL, "u1")
+
+	sendCommand(t, a, SyncCommand{ID: "1", Op: "add", AssetID: "sse-chart", Description: "mine"})
+	if ack := readMessage(t, a); ack.Type != "ack" || ack.ID != "1" || ack.Status != http.StatusCreated {
+		t.Fatalf("unexpected ack %+v", ack)
+	}
+
+	push := readMessage(t, b)
+	if push.Type != "event" || push.Event == nil || push.Event.AssetID != "sse-chart" || push.Event.Description != "mine" {
+		t.Fatalf("unexpected push %+v", push)
+	}
+
+	// A must not receive an echo of its own change; the next message it
+	// sees is the ack for its next command.
+	sendCommand(t, a, SyncCommand{ID: "2", Op: "add", AssetID: "sse-chart"})
+	if ack := readMessage(t, a); ack.ID != "2" || ack.Status != http.StatusConflict {
+		t.Fatalf("expected conflict ack, got %+v", ack)
+	}
+
+	sendCommand(t, b, SyncCommand{ID: "3", Op: "edit", AssetID: "sse-chart", Description: "theirs"})
+	if ack := readMessage(t, b); ack.ID != "3" || ack.Status != http.StatusNoContent {
+		t.Fatalf("unexpected edit ack %+v", ack)
+	}
+	if push := readMessage(t, a); push.Event == nil || push.Event.Description != "theirs" {
+		t.Fatalf("expected edit push, got %+v", push)
+	}
+
+	// The REST API sees the same state.
+	res, err := http.Get(srv.URL + "/users/u1/favorites")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var favs []map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&favs)
+	if len(favs) != 1 || favs[0]["description"] != "theirs" {
+		t.Errorf("unexpected REST state %v", favs)
+	}
+}
+
+func TestFavoritesSync_InvalidCommands(t *testing.T) {
+	srv, _ := setupEventsServer(t)
+	c := dialSync(t, srv.URL, "u1")
+
+	tests := []struct {
+		cmd  SyncCommand
+		want int
+	}{
+		{SyncCommand{ID: "a", Op: "fly", AssetID: "sse-chart"}, http.StatusBadRequest},
+		{SyncCommand{ID: "b", Op: "add"}, http.StatusBadRequest},
+		{SyncCommand{ID: "c", Op: "add", AssetID: "not-in-catalog"}, http.StatusNotFound},
+		{SyncCommand{ID: "d", Op: "edit", AssetID: "sse-chart"}, http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		sendCommand(t, c, tc.cmd)
+		ack := readMessage(t, c)
+		if ack.ID != tc.cmd.ID || ack.Status != tc.want || ack.Error == "" {
+			t.Errorf("%s: expected %d with error, got %+v", tc.cmd.ID, tc.want, ack)
+		}
+	}
+
+	c.WriteMessage(ws.OpText, []byte("{not json"), time.Now().Add(time.Second))
+	if ack := readMessage(t, c); ack.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 for malformed command, got %+v", ack)
+	}
+}
+
+func TestFavoritesSync_PushesBacklog(t *testing.T) {
+	srv, bus := setupEventsServer(t)
+	first := bus.Publish(events.Event{Type: events.FavoriteAdded, UserID: "u1", AssetID: "sse-chart"})
+	bus.Publish(events.Event{Type: events.DescriptionEdited, UserID: "u1", AssetID: "sse-chart", Description: "later"})
+
+	// Without lastEventId every buffered event is pushed before live ones.
+	c := dialSync(t, srv.URL, "u1")
+	for _, want := range []uint64{1, 2} {
+		if msg := readMessage(t, c); msg.Type != "event" || msg.Event == nil || msg.Event.Seq != want {
+			t.Fatalf("expected backlog event %d, got %+v", want, msg)
+		}
+	}
+	bus.Publish(events.Event{Type: events.FavoriteRemoved, UserID: "u1", AssetID: "sse-chart"})
+	if msg := readMessage(t, c); msg.Event == nil || msg.Event.Seq != 3 {
+		t.Fatalf("expected live event 3, got %+v", msg)
+	}
+
+	// A client resuming after the first event only gets the rest.
+	c = dialSyncQuery(t, srv.URL, "u1", fmt.Sprintf("?lastEventId=%d", first.Seq))
+	if msg := readMessage(t, c); msg.Event == nil || msg.Event.Description != "later" {
+		t.Fatalf("expected backlog from event 2, got %+v", msg)
+	}
+
+	res, err := http.Get(srv.URL + "/users/u1/favorites/ws?lastEventId=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid lastEventId, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+// trash returns the store's trash, writing 501 if the backend removes
+// favorites permanently.
+func (api *API) trash(w http.ResponseWriter) (store.Trash, bool) {
+	t, ok := store.Find[store.Trash](api.Store)
+	if !ok {
+		http.Error(w, "trash not supported by store", http.StatusNotImplemented)
+	}
+	return t, ok
+}
+
+// listTrashHandler lists a user's removed favorites.
+// @Summary List removed favorites
+// @Description Favorites removed by the user that can still be restored, most recently removed first.
+// @Description Each item's expiresAt is when it will be permanently purged.
+// @Tags favorites
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {array} models.TrashedFavorite
+// @Failure 500 {string} string "Internal server error"
+// @Failure 501 {string} string "Not supported by store"
+// @Router /users/{id}/favorites/trash [get]
+func (api *API) listTrashHandler(w http.ResponseWriter, r *http.Request) {
+	t, ok := api.trash(w)
+	if !ok {
+		return
+	}
+	items, err := t.ListTrash(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		if errors.Is(err, store.ErrUnsupported) {
+			http.Error(w, "trash not supported by store", http.StatusNotImplemented)
+			return
+		}
+		if writeContextError(w, err) {
+			return
+		}
+		http.Error(w, "failed to list trash", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// restoreFavoriteHandler moves a removed favorite back into the user's list.
+// @Summary Restore a removed favorite
+// @Description Restores the favorite with its original description, creation time and position
+// @Tags favorites
+// @Param id path string true "User ID"
+// @Param assetID path string true "Asset ID"
+// @Success 204 {string} string "No Content"
+// @Failure 404 {string} string "Not in trash"
+// @Failure 409 {string} string "Asset already favorited"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 501 {string} string "Not supported by store"
+// @Router /users/{id}/favorites/trash/{assetID}/restore [post]
+func (api *API) restoreFavoriteHandler(w http.ResponseWriter, r *http.Request) {
+	t, ok := api.trash(w)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	if err := t.RestoreFavorite(r.Context(), vars["id"], vars["assetID"]); err != nil {
+		switch {
+		case errors.Is(err, store.ErrAssetNotFound):
+			http.Error(w, "favorite not in trash", http.StatusNotFound)
+		case errors.Is(err, store.ErrAlreadyFavorited):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, store.ErrUnsupported):
+			http.Error(w, "trash not supported by store", http.StatusNotImplemented)
+		case writeContextError(w, err):
+		default:
+			http.Error(w, "failed to restore favorite", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
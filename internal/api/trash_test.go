@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/eventlog"
+	"my-solution/internal/events"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestTrashHandlers(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("t1", models.Chart{AssetBase: models.AssetBase{ID: "t1", Name: "Trash me"}, ChartType: "bar"})
+	r, s := setupRouter()
+	s.AddFavorite(context.Background(), "u1", "t1", "keep this note")
+
+	if res := executeRequest(r, "DELETE", "/users/u1/favorites/t1", nil); res.Code != http.StatusNoContent {
+		t.Fatalf("remove: expected 204, got %d", res.Code)
+	}
+
+	var trash []models.TrashedFavorite
+	res := executeRequest(r, "GET", "/users/u1/favorites/trash", nil)
+	if res.Code != http.StatusOK {
+		t.Fatalf("list trash: expected 200, got %d", res.Code)
+	}
+	json.NewDecoder(res.Body).Decode(&trash)
+	if len(trash) != 1 || trash[0].AssetID != "t1" || trash[0].ExpiresAt.IsZero() {
+		t.Fatalf("unexpected trash %+v", trash)
+	}
+
+	if res := executeRequest(r, "POST", "/users/u1/favorites/trash/t1/restore", nil); res.Code != http.StatusNoContent {
+		t.Fatalf("restore: expected 204, got %d", res.Code)
+	}
+	favs, _ := s.ListFavorites(context.Background(), "u1")
+	if len(favs) != 1 || favs[0].Description != "keep this note" {
+		t.Fatalf("restore should bring back the favorite, got %+v", favs)
+	}
+
+	if res := executeRequest(r, "POST", "/users/u1/favorites/trash/t1/restore", nil); res.Code != http.StatusNotFound {
+		t.Errorf("restore again: expected 404, got %d", res.Code)
+	}
+
+	s.RemoveFavorite(context.Background(), "u1", "t1")
+	s.AddFavorite(context.Background(), "u1", "t1", "")
+	if res := executeRequest(r, "POST", "/users/u1/favorites/trash/t1/restore", nil); res.Code != http.StatusConflict {
+		t.Errorf("restore over existing: expected 409, got %d", res.Code)
+	}
+}
+
+func TestTrashUnsupported(t *testing.T) {
+	api := &API{Store: store.FromLegacy(store.Legacy(store.NewMemoryStore()))}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+	if res := executeRequest(r, "GET", "/users/u1/favorites/trash", nil); res.Code != http.StatusNotImplemented {
+		t.Errorf("expected 501, got %d", res.Code)
+	}
+}
+
+func TestTrashUnsupportedBehindDecorators(t *testing.T) {
+	el, err := eventlog.OpenStore(t.TempDir(), eventlog.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer el.Close()
+	h := history.NewStore(events.NewStore(el, events.NewBus(0)))
+	api := &API{Store: h, History: h}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	if res := executeRequest(r, "GET", "/v1/users/u1/favorites/trash", nil); res.Code != http.StatusNotImplemented {
+		t.Errorf("list: expected 501, got %d", res.Code)
+	}
+	if res := executeRequest(r, "POST", "/v1/users/u1/favorites/trash/t1/restore", nil); res.Code != http.StatusNotImplemented {
+		t.Errorf("restore: expected 501, got %d", res.Code)
+	}
+	if _, ok := store.Find[store.Trash](h); ok {
+		t.Error("expected decorators not to expose a trash the store lacks")
+	}
+}
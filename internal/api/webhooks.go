@@ -0,0 +1,213 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"my-solution/internal/events"
+	"my-solution/internal/webhooks"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateWebhookRequest defines the body for registering a webhook.
+type CreateWebhookRequest struct {
+	URL        string        `json:"url"`
+	EventTypes []events.Type `json:"eventTypes"` // Empty subscribes to all event types
+	Secret     string        `json:"secret"`     // Used to sign deliveries; not returned
+}
+
+// webhooksEnabled writes 501 and returns false when webhooks are not configured.
+func (api *API) webhooksEnabled(w http.ResponseWriter) bool {
+	if api.Webhooks == nil {
+		http.Error(w, "webhooks not enabled", http.StatusNotImplemented)
+		return false
+	}
+	return true
+}
+
+// createWebhookHandler registers a webhook subscription.
+// @Summary Create webhook subscription
+// @Description Register a URL to receive signed favorite change events
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body api.CreateWebhookRequest true "Subscription"
+// @Success 201 {object} webhooks.Subscription
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Router /admin/webhooks [post]
+func (api *API) createWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := api.Webhooks.Registry.Create(webhooks.Subscription{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sub)
+}
+
+// listWebhooksHandler lists webhook subscriptions.
+// @Summary List webhook subscriptions
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} webhooks.Subscription
+// @Failure 401 {string} string "Unauthorized"
+// @Router /admin/webhooks [get]
+func (api *API) listWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.Webhooks.Registry.List())
+}
+
+// getWebhookHandler returns one webhook subscription.
+// @Summary Get webhook subscription
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param subID path string true "Subscription ID"
+// @Success 200 {object} webhooks.Subscription
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Subscription not found"
+// @Router /admin/webhooks/{subID} [get]
+func (api *API) getWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	sub, err := api.Webhooks.Registry.Get(mux.Vars(r)["subID"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sub)
+}
+
+// deleteWebhookHandler removes a webhook subscription.
+// @Summary Delete webhook subscription
+// @Tags admin
+// @Security AdminToken
+// @Param subID path string true "Subscription ID"
+// @Success 204 {string} string "No Content"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Subscription not found"
+// @Router /admin/webhooks/{subID} [delete]
+func (api *API) deleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	if err := api.Webhooks.Registry.Delete(mux.Vars(r)["subID"]); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookDeliveriesHandler returns the delivery log for a subscription.
+// @Summary Webhook delivery log
+// @Description Most recent delivery attempts first
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param subID path string true "Subscription ID"
+// @Param failed query bool false "Only failed attempts"
+// @Param limit query int false "Maximum entries to return"
+// @Success 200 {array} webhooks.Attempt
+// @Failure 400 {string} string "Bad request"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Subscription not found"
+// @Router /admin/webhooks/{subID}/deliveries [get]
+func (api *API) webhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	subID := mux.Vars(r)["subID"]
+	if _, err := api.Webhooks.Registry.Get(subID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	filter := webhooks.LogFilter{SubscriptionID: subID}
+	q := r.URL.Query()
+	if v := q.Get("failed"); v != "" {
+		failed, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid failed parameter", http.StatusBadRequest)
+			return
+		}
+		filter.FailedOnly = failed
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.Webhooks.Log(filter))
+}
+
+// listDeadLettersHandler lists deliveries that exhausted their retries.
+// @Summary List dead-lettered deliveries
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} webhooks.Delivery
+// @Failure 401 {string} string "Unauthorized"
+// @Router /admin/webhooks/dead-letters [get]
+func (api *API) listDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.Webhooks.DeadLetters())
+}
+
+// redeliverHandler requeues a dead-lettered delivery.
+// @Summary Redeliver a dead-lettered delivery
+// @Tags admin
+// @Security AdminToken
+// @Param deliveryID path string true "Delivery ID"
+// @Success 202 {string} string "Accepted"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 404 {string} string "Delivery not found"
+// @Router /admin/webhooks/dead-letters/{deliveryID}/redeliver [post]
+func (api *API) redeliverHandler(w http.ResponseWriter, r *http.Request) {
+	if !api.webhooksEnabled(w) {
+		return
+	}
+	if err := api.Webhooks.Redeliver(mux.Vars(r)["deliveryID"]); err != nil {
+		if errors.Is(err, webhooks.ErrDeliveryNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "failed to redeliver", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
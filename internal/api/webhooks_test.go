@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+	"my-solution/internal/webhooks"
+
+	"github.com/gorilla/mux"
+)
+
+const testAdminToken = "test-admin-token"
+
+func adminRequest(method, path string, payload interface{}) *http.Request {
+	var body []byte
+	if payload != nil {
+		body, _ = json.Marshal(payload)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	return req
+}
+
+func TestAdminAuth(t *testing.T) {
+	api := &API{Store: store.NewMemoryStore(), Webhooks: webhooks.NewDispatcher(webhooks.NewRegistry(), webhooks.Options{})}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	// Disabled without a configured token.
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/webhooks", nil))
+	if res.Code != http.StatusForbidden {
+		t.Errorf("expected 403 with admin disabled, got %d", res.Code)
+	}
+
+	api.AdminToken = testAdminToken
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest("GET", "/admin/webhooks", nil))
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without token, got %d", res.Code)
+	}
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/webhooks", nil))
+	if res.Code != http.StatusOK {
+		t.Errorf("expected 200 with token, got %d", res.Code)
+	}
+}
+
+func TestWebhooks_EndToEnd(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("wh-chart", models.Chart{
+		AssetBase: models.AssetBase{ID: "wh-chart", Name: "Chart"},
+		ChartType: "bar",
+	})
+
+	received := make(chan webhooks.Payload, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var p webhooks.Payload
+		json.Unmarshal(body, &p)
+		received <- p
+	}))
+	defer receiver.Close()
+
+	bus := events.NewBus(0)
+	dispatcher := webhooks.NewDispatcher(webhooks.NewRegistry(), webhooks.Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() { cancel(); dispatcher.Wait() }()
+	dispatcher.Start(ctx)
+	bus.AddListener(dispatcher.Enqueue)
+
+	api := &API{
+		Store:      events.NewStore(store.NewMemoryStore(), bus),
+		Events:     bus,
+		Webhooks:   dispatcher,
+		AdminToken: testAdminToken,
+	}
+	r := mux.NewRouter()
+	api.RegisterHandlers(r)
+
+	// Register a subscription for edits only.
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("POST", "/admin/webhooks", CreateWebhookRequest{
+		URL:        receiver.URL,
+		Secret:     "s3cret",
+		EventTypes: []events.Type{events.DescriptionEdited},
+	}))
+	if res.Code != http.StatusCreated {
+		t.Fatalf("create webhook: %d %s", res.Code, res.Body.String())
+	}
+	var sub map[string]interface{}
+	json.NewDecoder(res.Body).Decode(&sub)
+	if _, leaked := sub["secret"]; leaked {
+		t.Error("secret must not be returned")
+	}
+	subID := sub["id"].(string)
+
+	// Invalid subscriptions are rejected.
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("POST", "/admin/webhooks", CreateWebhookRequest{URL: "not a url", Secret: "s"}))
+	if res.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid URL, got %d", res.Code)
+	}
+
+	executeRequest(r, "POST", "/users/u1/favorites", map[string]string{"assetId": "wh-chart"})
+	executeRequest(r, "PATCH", "/users/u1/favorites/wh-chart", map[string]string{"description": "edited"})
+
+	select {
+	case p := <-received:
+		if p.Type != events.DescriptionEdited || p.Data.Description != "edited" {
+			t.Errorf("unexpected delivery %+v", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook delivery received")
+	}
+
+	// The delivery log records the attempt.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		res = httptest.NewRecorder()
+		r.ServeHTTP(res, adminRequest("GET", "/admin/webhooks/"+subID+"/deliveries", nil))
+		var log []webhooks.Attempt
+		json.NewDecoder(res.Body).Decode(&log)
+		if len(log) == 1 && log[0].Success {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery log not updated: %+v", log)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("DELETE", "/admin/webhooks/"+subID, nil))
+	if res.Code != http.StatusNoContent {
+		t.Errorf("delete: expected 204, got %d", res.Code)
+	}
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, adminRequest("GET", "/admin/webhooks/"+subID, nil))
+	if res.Code != http.StatusNotFound {
+		t.Errorf("get deleted: expected 404, got %d", res.Code)
+	}
+}
@@ -0,0 +1,46 @@
+// Package apiv2 serves version 2 of the favorites API under /v2.
+//
+// v2 is where response shapes are free to change (envelopes, pagination,
+// type discriminators) without affecting /v1 clients. Its handlers live
+// here rather than in package api so that each version has its own
+// Swagger document, generated into docs/v2 with:
+//
+//	swag init -d ./internal/apiv2 -g apiv2.go -o ./docs/v2 --instanceName v2 --parseDependency
+package apiv2
+
+import (
+	"net/http"
+
+	"my-solution/internal/api"
+	"my-solution/pkg/health"
+
+	"github.com/gorilla/mux"
+)
+
+// API serves the v2 routes. It shares its backends with the v1 API.
+type API struct {
+	*api.API
+}
+
+// RegisterHandlers sets up all v2 API routes on the provided router, which
+// should be mounted at /v2.
+//
+// @title Favorites API
+// @version 2.0-alpha
+// @description Version 2 of the favorites API. Unstable: shapes may change until it is released.
+// @host localhost:8080
+// @BasePath /v2
+func (a *API) RegisterHandlers(r *mux.Router) {
+	r.HandleFunc("/healthz", healthHandler).Methods("GET")
+}
+
+// healthHandler returns service health and version.
+// @Summary Health check
+// @Description Returns service status and version.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.HealthResponse
+// @Router /healthz [get]
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	health.Handler(w, r)
+}
@@ -0,0 +1,31 @@
+package apiv2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-solution/internal/api"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMountedBesideV1(t *testing.T) {
+	v1 := &api.API{Store: store.NewMemoryStore()}
+	r := mux.NewRouter()
+	(&API{API: v1}).RegisterHandlers(r.PathPrefix("/v2").Subrouter())
+	v1.RegisterHandlers(r)
+
+	for path, want := range map[string]int{
+		"/v2/healthz":            http.StatusOK,
+		"/v1/users/u1/favorites": http.StatusOK,
+		"/v2/users/u1/favorites": http.StatusNotFound,
+	} {
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, httptest.NewRequest("GET", path, nil))
+		if res.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, res.Code)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package catalog
 
 import (
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 
 	"my-solution/internal/models"
@@ -12,8 +16,34 @@ import (
 // Catalog holds all available assets in the system.
 // This represents the "huge list of assets" that all users have access to.
 type Catalog struct {
-	mu     sync.RWMutex
-	assets map[string]models.Asset
+	mu      sync.RWMutex
+	assets  map[string]models.Asset
+	aliases map[string]string // retired asset ID -> ID of the asset that replaces it
+
+	// deprecated assets still resolve through Get, so existing favorites
+	// keep working, but are no longer listed.
+	deprecated map[string]bool
+
+	// path is the seed file changes are written back to, set by
+	// LoadFromFile. Without it changes are kept in memory only.
+	path string
+}
+
+// maxAliasHops bounds alias resolution so a corrupt chain cannot loop.
+const maxAliasHops = 16
+
+var (
+	ErrAliasTargetNotFound = errors.New("alias target not in catalog")
+	ErrAliasConflict       = errors.New("alias would shadow an existing asset or form a cycle")
+	ErrAssetExists         = errors.New("asset ID already in use")
+	ErrAssetNotFound       = errors.New("asset not in catalog")
+	ErrInvalidAsset        = errors.New("invalid asset")
+)
+
+// Alias records that an asset was republished under a new ID.
+type Alias struct {
+	OldID string `json:"oldId"`
+	NewID string `json:"newId"`
 }
 
 // Global is the singleton instance of the catalog.
@@ -22,7 +52,9 @@ var Global *Catalog
 // Initialize creates and loads the global catalog.
 func Initialize() {
 	Global = &Catalog{
-		assets: make(map[string]models.Asset),
+		assets:     make(map[string]models.Asset),
+		aliases:    make(map[string]string),
+		deprecated: make(map[string]bool),
 	}
 }
 
@@ -33,10 +65,39 @@ func (c *Catalog) AddAsset(id string, asset models.Asset) {
 	c.assets[id] = asset
 }
 
-// LoadFromFile loads assets from a JSON seed file.
+// seedData matches sample_data/seed_assets.json. Any asset may list the
+// IDs it replaces, which become aliases resolved by Get, and may be marked
+// deprecated.
+type seedData struct {
+	Charts    []seedChart    `json:"charts"`
+	Insights  []seedInsight  `json:"insights"`
+	Audiences []seedAudience `json:"audiences"`
+}
+
+type seedChart struct {
+	models.Chart
+	Replaces   []string `json:",omitempty"`
+	Deprecated bool     `json:",omitempty"`
+}
+
+type seedInsight struct {
+	models.Insight
+	Replaces   []string `json:",omitempty"`
+	Deprecated bool     `json:",omitempty"`
+}
+
+type seedAudience struct {
+	models.Audience
+	Replaces   []string `json:",omitempty"`
+	Deprecated bool     `json:",omitempty"`
+}
+
+// LoadFromFile loads assets from a JSON seed file. Changes made through
+// Create, Update, Deprecate and Delete are written back to it.
 func (c *Catalog) LoadFromFile(path string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.path = path
 
 	file, err := os.Open(path)
 	if err != nil {
@@ -48,57 +109,391 @@ func (c *Catalog) LoadFromFile(path string) error {
 	}
 	defer file.Close()
 
-	// Structure matches scripts/seed_assets.json
-	type SeedData struct {
-		Charts    []models.Chart    `json:"charts"`
-		Insights  []models.Insight  `json:"insights"`
-		Audiences []models.Audience `json:"audiences"`
-	}
-
-	var data SeedData
+	var data seedData
 	if err := json.NewDecoder(file).Decode(&data); err != nil {
 		return fmt.Errorf("failed to parse catalog file: %w", err)
 	}
 
+	replaces := make(map[string][]string)
+
 	// Load charts
 	for _, chart := range data.Charts {
-		c.assets[chart.ID] = chart
+		c.assets[chart.ID] = chart.Chart
+		replaces[chart.ID] = chart.Replaces
+		c.deprecated[chart.ID] = chart.Deprecated
 	}
 
 	// Load insights
 	for _, insight := range data.Insights {
-		c.assets[insight.ID] = insight
+		c.assets[insight.ID] = insight.Insight
+		replaces[insight.ID] = insight.Replaces
+		c.deprecated[insight.ID] = insight.Deprecated
 	}
 
 	// Load audiences
 	for _, audience := range data.Audiences {
-		c.assets[audience.ID] = audience
+		c.assets[audience.ID] = audience.Audience
+		replaces[audience.ID] = audience.Replaces
+		c.deprecated[audience.ID] = audience.Deprecated
 	}
 
+	// Register aliases once every asset is loaded, so order does not matter
+	for newID, oldIDs := range replaces {
+		for _, oldID := range oldIDs {
+			if err := c.addAliasLocked(oldID, newID); err != nil {
+				return fmt.Errorf("asset %s replaces %s: %w", newID, oldID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAlias records that oldID has been replaced by newID, so Get(oldID)
+// returns the new asset. newID must be in the catalog and oldID must not.
+func (c *Catalog) AddAlias(oldID, newID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.addAliasLocked(oldID, newID)
+}
+
+func (c *Catalog) addAliasLocked(oldID, newID string) error {
+	if _, ok := c.assets[newID]; !ok {
+		return ErrAliasTargetNotFound
+	}
+	if _, ok := c.assets[oldID]; ok || oldID == newID {
+		return ErrAliasConflict
+	}
+	c.aliases[oldID] = newID
 	return nil
 }
 
-// Get retrieves an asset by ID.
+// Resolve follows aliases from id and returns the ID of the current asset.
+// It reports false if id resolves to nothing in the catalog.
+func (c *Catalog) Resolve(id string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resolveLocked(id)
+}
+
+func (c *Catalog) resolveLocked(id string) (string, bool) {
+	for i := 0; i <= maxAliasHops; i++ {
+		if _, ok := c.assets[id]; ok {
+			return id, true
+		}
+		next, ok := c.aliases[id]
+		if !ok {
+			return "", false
+		}
+		id = next
+	}
+	return "", false
+}
+
+// Aliases returns every alias with its fully resolved target, sorted by
+// old ID. Aliases whose target has since been removed are omitted.
+func (c *Catalog) Aliases() []Alias {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]Alias, 0, len(c.aliases))
+	for oldID := range c.aliases {
+		if newID, ok := c.resolveLocked(oldID); ok {
+			result = append(result, Alias{OldID: oldID, NewID: newID})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].OldID < result[j].OldID })
+	return result
+}
+
+// Get retrieves an asset by ID, following aliases of replaced assets.
 func (c *Catalog) Get(id string) (models.Asset, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	asset, ok := c.assets[id]
-	return asset, ok
+	id, ok := c.resolveLocked(id)
+	if !ok {
+		return nil, false
+	}
+	return c.assets[id], true
+}
+
+// GetMany retrieves several assets at once, following aliases. IDs that
+// resolve to nothing are absent from the result. It takes the catalog lock
+// once, so callers resolving many IDs should prefer it over Get.
+func (c *Catalog) GetMany(ids []string) map[string]models.Asset {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make(map[string]models.Asset, len(ids))
+	for _, id := range ids {
+		if resolved, ok := c.resolveLocked(id); ok {
+			result[id] = c.assets[resolved]
+		}
+	}
+	return result
 }
 
-// List returns all available assets.
+// List returns all available assets. Deprecated assets are left out.
 func (c *Catalog) List() []models.Asset {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	result := make([]models.Asset, 0, len(c.assets))
-	for _, asset := range c.assets {
-		result = append(result, asset)
+	for id, asset := range c.assets {
+		if !c.deprecated[id] {
+			result = append(result, asset)
+		}
+	}
+	return result
+}
+
+// Entry is an asset with its catalog metadata, as managed by admins.
+type Entry struct {
+	Type       string       `json:"type"`
+	Asset      models.Asset `json:"asset"`
+	Deprecated bool         `json:"deprecated"`
+	Replaces   []string     `json:"replaces,omitempty"` // Retired IDs that resolve to this asset
+}
+
+// Entries returns every asset, including deprecated ones, sorted by ID.
+func (c *Catalog) Entries() []Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	replaces := c.replacesLocked()
+	result := make([]Entry, 0, len(c.assets))
+	for id := range c.assets {
+		result = append(result, c.entryLocked(id, replaces))
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Asset.GetID() < result[j].Asset.GetID() })
 	return result
 }
 
+// Entry returns the asset with exactly this ID, not following aliases.
+func (c *Catalog) Entry(id string) (Entry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if _, ok := c.assets[id]; !ok {
+		return Entry{}, false
+	}
+	return c.entryLocked(id, c.replacesLocked()), true
+}
+
+func (c *Catalog) entryLocked(id string, replaces map[string][]string) Entry {
+	asset := c.assets[id]
+	return Entry{
+		Type:       models.AssetType(asset),
+		Asset:      asset,
+		Deprecated: c.deprecated[id],
+		Replaces:   replaces[id],
+	}
+}
+
+// replacesLocked inverts the aliases: asset ID -> sorted retired IDs that
+// resolve to it.
+func (c *Catalog) replacesLocked() map[string][]string {
+	replaces := make(map[string][]string)
+	for oldID := range c.aliases {
+		if newID, ok := c.resolveLocked(oldID); ok {
+			replaces[newID] = append(replaces[newID], oldID)
+		}
+	}
+	for _, ids := range replaces {
+		sort.Strings(ids)
+	}
+	return replaces
+}
+
+// Create validates asset and adds it to the catalog, assigning a new ID if
+// it has none. It returns the asset as stored.
+func (c *Catalog) Create(asset models.Asset) (models.Asset, error) {
+	if asset.GetID() == "" {
+		asset = withID(asset, newID())
+	}
+	if err := validate(asset); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := asset.GetID()
+	if _, ok := c.assets[id]; ok {
+		return nil, ErrAssetExists
+	}
+	if _, ok := c.aliases[id]; ok {
+		return nil, ErrAssetExists
+	}
+	c.assets[id] = asset
+	if err := c.saveLocked(); err != nil {
+		delete(c.assets, id)
+		return nil, err
+	}
+	return asset, nil
+}
+
+// Update validates asset and replaces the catalog asset with the same ID.
+// The asset type may change; aliases and deprecation are kept.
+func (c *Catalog) Update(asset models.Asset) error {
+	if err := validate(asset); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := asset.GetID()
+	prev, ok := c.assets[id]
+	if !ok {
+		return ErrAssetNotFound
+	}
+	c.assets[id] = asset
+	if err := c.saveLocked(); err != nil {
+		c.assets[id] = prev
+		return err
+	}
+	return nil
+}
+
+// Deprecate marks the asset deprecated, or no longer deprecated. Deprecated
+// assets are hidden from List but still resolve through Get.
+func (c *Catalog) Deprecate(id string, deprecated bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.assets[id]; !ok {
+		return ErrAssetNotFound
+	}
+	prev := c.deprecated[id]
+	c.deprecated[id] = deprecated
+	if err := c.saveLocked(); err != nil {
+		c.deprecated[id] = prev
+		return err
+	}
+	return nil
+}
+
+// Delete removes the asset. Favorites of it become orphans, and aliases
+// that pointed at it no longer resolve.
+func (c *Catalog) Delete(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prev, ok := c.assets[id]
+	if !ok {
+		return ErrAssetNotFound
+	}
+	wasDeprecated := c.deprecated[id]
+	delete(c.assets, id)
+	delete(c.deprecated, id)
+	if err := c.saveLocked(); err != nil {
+		c.assets[id] = prev
+		c.deprecated[id] = wasDeprecated
+		return err
+	}
+	return nil
+}
+
+// saveLocked writes the catalog back to the file it was loaded from, if
+// any, replacing it atomically. Aliases are written as the Replaces of
+// their target; aliases whose target was deleted are dropped.
+func (c *Catalog) saveLocked() error {
+	if c.path == "" {
+		return nil
+	}
+
+	data := seedData{
+		Charts:    []seedChart{},
+		Insights:  []seedInsight{},
+		Audiences: []seedAudience{},
+	}
+	replaces := c.replacesLocked()
+	ids := make([]string, 0, len(c.assets))
+	for id := range c.assets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		switch a := c.assets[id].(type) {
+		case models.Chart:
+			data.Charts = append(data.Charts, seedChart{a, replaces[id], c.deprecated[id]})
+		case *models.Chart:
+			data.Charts = append(data.Charts, seedChart{*a, replaces[id], c.deprecated[id]})
+		case models.Insight:
+			data.Insights = append(data.Insights, seedInsight{a, replaces[id], c.deprecated[id]})
+		case *models.Insight:
+			data.Insights = append(data.Insights, seedInsight{*a, replaces[id], c.deprecated[id]})
+		case models.Audience:
+			data.Audiences = append(data.Audiences, seedAudience{a, replaces[id], c.deprecated[id]})
+		case *models.Audience:
+			data.Audiences = append(data.Audiences, seedAudience{*a, replaces[id], c.deprecated[id]})
+		}
+	}
+
+	buf, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(buf, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write catalog file: %w", err)
+	}
+	return nil
+}
+
+// validate runs the asset's own checks, wrapping failures in
+// ErrInvalidAsset.
+func validate(asset models.Asset) error {
+	if models.AssetType(asset) == "" {
+		return fmt.Errorf("%w: unknown asset type", ErrInvalidAsset)
+	}
+	if asset.GetID() == "" {
+		return fmt.Errorf("%w: id is required", ErrInvalidAsset)
+	}
+	if err := asset.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAsset, err)
+	}
+	return nil
+}
+
+// withID returns a copy of asset with its ID set.
+func withID(asset models.Asset, id string) models.Asset {
+	switch a := asset.(type) {
+	case models.Chart:
+		a.ID = id
+		return a
+	case models.Insight:
+		a.ID = id
+		return a
+	case models.Audience:
+		a.ID = id
+		return a
+	}
+	return asset
+}
+
+// newID returns a random (version 4) UUID, the form of the seed asset IDs.
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
 // Count returns the total number of assets in the catalog.
 func (c *Catalog) Count() int {
 	c.mu.RLock()
@@ -0,0 +1,92 @@
+package catalog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"my-solution/internal/models"
+)
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	seed := `{"charts": [{"ID": "c1", "Name": "Revenue", "ChartType": "bar", "Replaces": ["c0"]}]}`
+	if err := os.WriteFile(path, []byte(seed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	Initialize()
+	if err := Global.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Global.Create(models.Chart{AssetBase: models.AssetBase{Name: "No chart type"}}); !errors.Is(err, ErrInvalidAsset) {
+		t.Errorf("expected ErrInvalidAsset, got %v", err)
+	}
+	if _, err := Global.Create(models.Chart{AssetBase: models.AssetBase{ID: "c0", Name: "Old"}, ChartType: "pie"}); !errors.Is(err, ErrAssetExists) {
+		t.Errorf("expected an alias ID to be taken, got %v", err)
+	}
+	created, err := Global.Create(models.Audience{AssetBase: models.AssetBase{Name: "Gen Z"}, Segment: "18-24", Size: 100})
+	if err != nil || created.GetID() == "" {
+		t.Fatalf("create: %v %+v", err, created)
+	}
+	if err := Global.Update(models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "X"}, Metric: "m", Value: "v"}); !errors.Is(err, ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound, got %v", err)
+	}
+	if err := Global.Update(models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Revenue (EUR)"}, ChartType: "line"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Global.Deprecate("c1", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(Global.List()) != 1 {
+		t.Errorf("expected deprecated asset to be unlisted, got %v", Global.List())
+	}
+	if a, ok := Global.Get("c0"); !ok || a.GetName() != "Revenue (EUR)" {
+		t.Errorf("expected deprecated asset to resolve through its alias, got %v", a)
+	}
+
+	// A fresh catalog loaded from the file sees every change.
+	Initialize()
+	if err := Global.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	entries := Global.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+	for _, e := range entries {
+		switch e.Asset.GetID() {
+		case "c1":
+			if !e.Deprecated || e.Asset.(models.Chart).ChartType != "line" || len(e.Replaces) != 1 {
+				t.Errorf("unexpected reloaded chart %+v", e)
+			}
+		case created.GetID():
+			if e.Type != models.TypeAudience || e.Asset != created {
+				t.Errorf("unexpected reloaded audience %+v", e)
+			}
+		}
+	}
+
+	if err := Global.Delete("c1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Global.Get("c0"); ok {
+		t.Error("expected the alias of a deleted asset to stop resolving")
+	}
+	if err := Global.Delete("c1"); !errors.Is(err, ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound, got %v", err)
+	}
+}
+
+func TestSaveFailureRollsBack(t *testing.T) {
+	Initialize()
+	Global.path = filepath.Join(t.TempDir(), "missing", "catalog.json")
+
+	if _, err := Global.Create(models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "C"}, ChartType: "bar"}); err == nil {
+		t.Fatal("expected the write to fail")
+	}
+	if Global.Count() != 0 {
+		t.Error("expected the failed create to be rolled back")
+	}
+}
@@ -0,0 +1,184 @@
+// Package dashboard builds the frontpage dashboard: a user's favorites
+// grouped by asset type, most recent first, as compact tiles.
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+// SummaryLength is the maximum length, in runes, of a tile's summary.
+const SummaryLength = 120
+
+// Tile is a favorite sized for a dashboard tile.
+type Tile struct {
+	AssetID     string    `json:"assetId"`
+	Name        string    `json:"name"`
+	Headline    string    `json:"headline"`          // e.g. "bar chart", "Engagement: 40%", "18-24 (12000)"
+	Summary     string    `json:"summary,omitempty"` // Asset description, truncated to SummaryLength
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Group is the favorites of one asset type.
+type Group struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"` // All favorites of this type, not just Items
+	Items []Tile `json:"items"`
+}
+
+// Dashboard is a user's favorites grouped by type.
+type Dashboard struct {
+	UserID string  `json:"userId"`
+	Total  int     `json:"total"`
+	Groups []Group `json:"groups"` // Charts, insights, audiences, always in that order
+}
+
+// groupOrder is the order of groups on the dashboard.
+var groupOrder = []string{models.TypeChart, models.TypeInsight, models.TypeAudience}
+
+// Build groups favorites by asset type, most recently favorited first.
+// Favorites whose asset is unavailable are left out.
+func Build(userID string, favorites []models.FavoriteWithAsset) Dashboard {
+	byType := make(map[string][]Tile, len(groupOrder))
+	total := 0
+	for _, fav := range favorites {
+		if fav.Asset == nil {
+			continue
+		}
+		t := models.AssetType(fav.Asset)
+		byType[t] = append(byType[t], tile(fav))
+		total++
+	}
+
+	d := Dashboard{UserID: userID, Total: total, Groups: make([]Group, 0, len(groupOrder))}
+	for _, t := range groupOrder {
+		items := byType[t]
+		if items == nil {
+			items = make([]Tile, 0)
+		}
+		sort.SliceStable(items, func(i, j int) bool { return items[i].CreatedAt.After(items[j].CreatedAt) })
+		d.Groups = append(d.Groups, Group{Type: t, Count: len(items), Items: items})
+	}
+	return d
+}
+
+// Top returns a copy of d keeping at most n items per group.
+func (d Dashboard) Top(n int) Dashboard {
+	groups := make([]Group, len(d.Groups))
+	for i, g := range d.Groups {
+		if len(g.Items) > n {
+			g.Items = g.Items[:n]
+		}
+		groups[i] = g
+	}
+	d.Groups = groups
+	return d
+}
+
+func tile(fav models.FavoriteWithAsset) Tile {
+	t := Tile{
+		AssetID:     fav.AssetID,
+		Name:        fav.Asset.GetName(),
+		Summary:     truncate(fav.Asset.GetDescription(), SummaryLength),
+		Description: fav.Description,
+		CreatedAt:   fav.CreatedAt,
+	}
+	switch a := fav.Asset.(type) {
+	case models.Chart:
+		t.Headline = a.ChartType + " chart"
+	case *models.Chart:
+		t.Headline = a.ChartType + " chart"
+	case models.Insight:
+		t.Headline = a.Metric + ": " + a.Value
+	case *models.Insight:
+		t.Headline = a.Metric + ": " + a.Value
+	case models.Audience:
+		t.Headline = fmt.Sprintf("%s (%d)", a.Segment, a.Size)
+	case *models.Audience:
+		t.Headline = fmt.Sprintf("%s (%d)", a.Segment, a.Size)
+	}
+	return t
+}
+
+// truncate shortens s to at most n runes, ending in an ellipsis if cut.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// Cache keeps each user's dashboard until their favorites change. Feed it
+// favorite events with Observe so that it knows when to rebuild.
+type Cache struct {
+	Store store.Store
+
+	mu      sync.Mutex
+	entries map[string]Dashboard
+	gen     map[string]uint64 // Bumped on every invalidation
+	epoch   uint64            // Bumped by InvalidateAll
+}
+
+// NewCache returns a cache building dashboards from s.
+func NewCache(s store.Store) *Cache {
+	return &Cache{Store: s, entries: make(map[string]Dashboard), gen: make(map[string]uint64)}
+}
+
+// Get returns the user's dashboard, building it from ListFavorites if it
+// is not cached.
+func (c *Cache) Get(ctx context.Context, userID string) (Dashboard, error) {
+	c.mu.Lock()
+	d, ok := c.entries[userID]
+	gen, epoch := c.gen[userID], c.epoch
+	c.mu.Unlock()
+	if ok {
+		return d, nil
+	}
+
+	favorites, err := c.Store.ListFavorites(ctx, userID)
+	if err != nil {
+		return Dashboard{}, err
+	}
+	d = Build(userID, favorites)
+
+	c.mu.Lock()
+	// Only cache if nothing changed while we were building.
+	if c.gen[userID] == gen && c.epoch == epoch {
+		c.entries[userID] = d
+	}
+	c.mu.Unlock()
+	return d, nil
+}
+
+// Invalidate drops the user's cached dashboard.
+func (c *Cache) Invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, userID)
+	c.gen[userID]++
+}
+
+// InvalidateAll drops every cached dashboard, for changes that may affect
+// any user, such as edits to the asset catalog.
+func (c *Cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.epoch++
+}
+
+// Observe invalidates the dashboard of the user an event is about. It is
+// meant to be registered with events.Bus.AddListener.
+func (c *Cache) Observe(e events.Event) {
+	c.Invalidate(e.UserID)
+}
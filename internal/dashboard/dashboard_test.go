@@ -0,0 +1,79 @@
+package dashboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+func TestBuild(t *testing.T) {
+	now := time.Now()
+	favorites := []models.FavoriteWithAsset{
+		{AssetID: "c1", CreatedAt: now.Add(-2 * time.Hour), Asset: models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Old"}, ChartType: "bar"}},
+		{AssetID: "c2", CreatedAt: now, Asset: models.Chart{AssetBase: models.AssetBase{ID: "c2", Name: "New", Description: strings.Repeat("x", 200)}, ChartType: "line"}},
+		{AssetID: "i1", CreatedAt: now, Asset: models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "I"}, Metric: "Engagement", Value: "40%"}},
+		{AssetID: "gone", CreatedAt: now, Status: models.StatusUnavailable},
+	}
+
+	d := Build("u1", favorites)
+	if d.Total != 3 || len(d.Groups) != 3 {
+		t.Fatalf("unexpected dashboard %+v", d)
+	}
+	charts, insights, audiences := d.Groups[0], d.Groups[1], d.Groups[2]
+	if charts.Type != models.TypeChart || charts.Count != 2 || charts.Items[0].AssetID != "c2" {
+		t.Errorf("charts should be most recent first, got %+v", charts)
+	}
+	if n := len([]rune(charts.Items[0].Summary)); n != SummaryLength {
+		t.Errorf("summary should be truncated to %d runes, got %d", SummaryLength, n)
+	}
+	if insights.Items[0].Headline != "Engagement: 40%" {
+		t.Errorf("unexpected headline %q", insights.Items[0].Headline)
+	}
+	if audiences.Count != 0 || audiences.Items == nil {
+		t.Errorf("empty group should have an empty item list, got %+v", audiences)
+	}
+
+	top := d.Top(1)
+	if len(top.Groups[0].Items) != 1 || top.Groups[0].Count != 2 || len(d.Groups[0].Items) != 2 {
+		t.Errorf("Top should trim items without changing counts or d, got %+v", top.Groups[0])
+	}
+}
+
+func TestCache(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("dc1", models.Chart{AssetBase: models.AssetBase{ID: "dc1", Name: "C"}, ChartType: "bar"})
+	catalog.Global.AddAsset("dc2", models.Chart{AssetBase: models.AssetBase{ID: "dc2", Name: "C"}, ChartType: "pie"})
+
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	c := NewCache(s)
+	s.AddFavorite(ctx, "u1", "dc1", "")
+
+	d, _ := c.Get(ctx, "u1")
+	if d.Total != 1 {
+		t.Fatalf("expected 1 favorite, got %d", d.Total)
+	}
+
+	// Written behind the cache's back: still served from cache.
+	s.AddFavorite(ctx, "u1", "dc2", "")
+	if d, _ = c.Get(ctx, "u1"); d.Total != 1 {
+		t.Errorf("expected cached dashboard, got %d favorites", d.Total)
+	}
+
+	c.Observe(events.Event{Type: events.FavoriteAdded, UserID: "u1", AssetID: "dc2"})
+	if d, _ = c.Get(ctx, "u1"); d.Total != 2 {
+		t.Errorf("expected rebuilt dashboard after event, got %d favorites", d.Total)
+	}
+
+	catalog.Global.Update(models.Chart{AssetBase: models.AssetBase{ID: "dc1", Name: "Renamed"}, ChartType: "bar"})
+	c.InvalidateAll()
+	if d, _ = c.Get(ctx, "u1"); d.Groups[0].Items[0].Name != "Renamed" && d.Groups[0].Items[1].Name != "Renamed" {
+		t.Errorf("expected rebuilt dashboard after InvalidateAll, got %+v", d.Groups[0].Items)
+	}
+}
@@ -0,0 +1,472 @@
+// Package eventlog stores favorites as an append-only log of events in a
+// directory of segment files. Current state, and any other read model, is
+// a projection rebuilt by replaying the log from offset zero.
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"my-solution/internal/events"
+)
+
+// ErrCorrupt is returned by Open when a segment holds a record that cannot
+// be decoded or is out of sequence.
+var ErrCorrupt = errors.New("event log corrupt")
+
+// ErrReadOnly is returned by methods that change the log when it was
+// opened with OpenReadOnly.
+var ErrReadOnly = errors.New("event log opened read-only")
+
+// errPastEnd stops a scan at records beyond the end of the log as loaded.
+var errPastEnd = errors.New("past end of log")
+
+// DefaultSegmentSize is the Options.SegmentSize used when it is zero.
+const DefaultSegmentSize = 4 << 20
+
+const segmentExt = ".log"
+
+// Redacted is the type of records whose content was erased by Redact.
+// Projections ignore it.
+const Redacted events.Type = "redacted"
+
+// Record is one favorite event in the log.
+type Record struct {
+	Offset      uint64      `json:"offset"` // Position in the log, starting at 0
+	Type        events.Type `json:"type"`
+	UserID      string      `json:"userId"`
+	AssetID     string      `json:"assetId"`
+	Description string      `json:"description,omitempty"` // New description for added/edited records
+	Time        time.Time   `json:"time"`
+}
+
+// Options configures a Log. Zero values select the defaults.
+type Options struct {
+	SegmentSize int64 // Bytes after which a new segment is started
+	Sync        bool  // fsync after every append
+}
+
+// Segment describes one file of the log.
+type Segment struct {
+	Base  uint64 `json:"base"`  // Offset of the first record
+	Count uint64 `json:"count"` // Number of records
+	Size  int64  `json:"size"`  // Bytes
+	Path  string `json:"path"`
+}
+
+// Log is a segmented append-only file of Records. Each segment is named
+// after the offset of its first record and holds one JSON record per line.
+type Log struct {
+	dir      string
+	opts     Options
+	readOnly bool
+
+	mu       sync.Mutex
+	segments []Segment
+	active   *os.File
+	next     uint64
+}
+
+// Open opens the log in dir, creating it if needed. A final record left
+// unterminated by a crash mid-append is discarded.
+func Open(dir string, opts Options) (*Log, error) {
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	l := &Log{dir: dir, opts: opts}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+
+	if len(l.segments) == 0 {
+		if err := l.roll(); err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+	if err := l.openActive(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// OpenReadOnly opens the existing log in dir for reading without changing
+// anything on disk, so it is safe to use while a server appends to it. A
+// final record that is still being written is skipped rather than
+// discarded, and records appended after OpenReadOnly returns are not seen.
+// Append, Truncate and Redact return ErrReadOnly.
+func OpenReadOnly(dir string) (*Log, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	l := &Log{dir: dir, readOnly: true}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// load scans the segments in l.dir and sets l.segments and l.next. Unless
+// the log is read-only, a torn final record is truncated away.
+func (l *Log) load() error {
+	paths, err := filepath.Glob(filepath.Join(l.dir, "*"+segmentExt))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		base, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), segmentExt), 10, 64)
+		if err != nil {
+			continue // Not a segment
+		}
+		l.segments = append(l.segments, Segment{Base: base, Path: path})
+	}
+	sort.Slice(l.segments, func(i, j int) bool { return l.segments[i].Base < l.segments[j].Base })
+
+	for i := range l.segments {
+		seg := &l.segments[i]
+		if seg.Base != l.next {
+			return fmt.Errorf("%w: segment %s starts at %d, expected %d", ErrCorrupt, seg.Path, seg.Base, l.next)
+		}
+		end, torn, err := scanSegment(seg.Path, seg.Base, func(r Record, pos int64) error {
+			seg.Count++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if torn {
+			if i != len(l.segments)-1 {
+				return fmt.Errorf("%w: unterminated record in %s", ErrCorrupt, seg.Path)
+			}
+			if !l.readOnly {
+				if err := os.Truncate(seg.Path, end); err != nil {
+					return err
+				}
+			}
+		}
+		seg.Size = end
+		l.next = seg.Base + seg.Count
+	}
+	return nil
+}
+
+// scanSegment calls fn with each record of the segment at path and the
+// byte position it starts at. It returns the position after the last
+// complete record and whether an unterminated record follows it.
+func scanSegment(path string, base uint64, fn func(r Record, pos int64) error) (int64, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var pos int64
+	expected := base
+	for {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			return pos, len(line) > 0, nil
+		}
+		if err != nil {
+			return pos, false, err
+		}
+		var r Record
+		if err := json.Unmarshal(line, &r); err != nil {
+			return pos, false, fmt.Errorf("%w: %s at byte %d: %v", ErrCorrupt, path, pos, err)
+		}
+		if r.Offset != expected {
+			return pos, false, fmt.Errorf("%w: %s at byte %d: offset %d, expected %d", ErrCorrupt, path, pos, r.Offset, expected)
+		}
+		if err := fn(r, pos); err != nil {
+			return pos, false, err
+		}
+		pos += int64(len(line))
+		expected++
+	}
+}
+
+// openActive opens the last segment for appending. Caller must hold l.mu
+// or have exclusive access.
+func (l *Log) openActive() error {
+	seg := l.segments[len(l.segments)-1]
+	f, err := os.OpenFile(seg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	l.active = f
+	return nil
+}
+
+// roll starts a new segment at the next offset. Caller must hold l.mu or
+// have exclusive access.
+func (l *Log) roll() error {
+	if l.active != nil {
+		if err := l.active.Close(); err != nil {
+			return err
+		}
+		l.active = nil
+	}
+	path := filepath.Join(l.dir, fmt.Sprintf("%020d%s", l.next, segmentExt))
+	l.segments = append(l.segments, Segment{Base: l.next, Path: path})
+	return l.openActive()
+}
+
+// Append writes r at the end of the log and returns it with its Offset set.
+func (l *Log) Append(r Record) (Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.readOnly {
+		return r, ErrReadOnly
+	}
+	if l.active == nil {
+		return r, os.ErrClosed
+	}
+	r.Offset = l.next
+	line, err := json.Marshal(r)
+	if err != nil {
+		return r, err
+	}
+	line = append(line, '\n')
+
+	seg := &l.segments[len(l.segments)-1]
+	if seg.Size > 0 && seg.Size+int64(len(line)) > l.opts.SegmentSize {
+		if err := l.roll(); err != nil {
+			return r, err
+		}
+		seg = &l.segments[len(l.segments)-1]
+	}
+	if _, err := l.active.Write(line); err != nil {
+		return r, err
+	}
+	if l.opts.Sync {
+		if err := l.active.Sync(); err != nil {
+			return r, err
+		}
+	}
+	seg.Size += int64(len(line))
+	seg.Count++
+	l.next++
+	return r, nil
+}
+
+// Read calls fn with every record at or after from, in order, stopping at
+// the first error fn returns. Appends wait until Read returns, so fn must
+// not append to the log.
+func (l *Log) Read(from uint64, fn func(Record) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, seg := range l.segments {
+		if i+1 < len(l.segments) && l.segments[i+1].Base <= from {
+			continue
+		}
+		_, _, err := scanSegment(seg.Path, seg.Base, func(r Record, _ int64) error {
+			if r.Offset >= l.next {
+				return errPastEnd // Appended by another process since the log was opened
+			}
+			if r.Offset < from {
+				return nil
+			}
+			return fn(r)
+		})
+		if err == errPastEnd {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Truncate discards every record at or after offset. Readers built from
+// the discarded records must be rebuilt.
+func (l *Log) Truncate(offset uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.readOnly {
+		return ErrReadOnly
+	}
+	if offset >= l.next {
+		return nil
+	}
+	if l.active != nil {
+		if err := l.active.Close(); err != nil {
+			return err
+		}
+		l.active = nil
+	}
+
+	// Drop whole segments after the one holding offset.
+	for len(l.segments) > 1 && l.segments[len(l.segments)-1].Base >= offset {
+		if err := os.Remove(l.segments[len(l.segments)-1].Path); err != nil {
+			return err
+		}
+		l.segments = l.segments[:len(l.segments)-1]
+	}
+
+	seg := &l.segments[len(l.segments)-1]
+	cut := seg.Size
+	_, _, err := scanSegment(seg.Path, seg.Base, func(r Record, pos int64) error {
+		if r.Offset == offset {
+			cut = pos
+			return io.EOF
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if err := os.Truncate(seg.Path, cut); err != nil {
+		return err
+	}
+	seg.Size = cut
+	seg.Count = offset - seg.Base
+	l.next = offset
+	return l.openActive()
+}
+
+// Redact rewrites every record matching match as a Redacted record at the
+// same offset, so that the data is gone but offsets held by readers stay
+// valid. It returns how many records were redacted.
+func (l *Log) Redact(match func(Record) bool) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.readOnly {
+		return 0, ErrReadOnly
+	}
+	redacted := 0
+	for i := range l.segments {
+		seg := &l.segments[i]
+		var (
+			buf     []byte
+			changed bool
+		)
+		_, _, err := scanSegment(seg.Path, seg.Base, func(r Record, _ int64) error {
+			if match(r) {
+				r = Record{Offset: r.Offset, Type: Redacted, Time: r.Time}
+				changed = true
+				redacted++
+			}
+			line, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			buf = append(append(buf, line...), '\n')
+			return nil
+		})
+		if err != nil {
+			return redacted, err
+		}
+		if !changed {
+			continue
+		}
+
+		active := i == len(l.segments)-1
+		if active && l.active != nil {
+			if err := l.active.Close(); err != nil {
+				return redacted, err
+			}
+			l.active = nil
+		}
+		if err := replaceFile(seg.Path, buf); err != nil {
+			return redacted, err
+		}
+		seg.Size = int64(len(buf))
+		if active {
+			if err := l.openActive(); err != nil {
+				return redacted, err
+			}
+		}
+	}
+	return redacted, nil
+}
+
+// replaceFile atomically replaces the file at path with data. Unlike
+// appends it always syncs, whatever Options.Sync says: a crash between an
+// unsynced write and the rename could otherwise leave the segment empty.
+func replaceFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return syncDir(filepath.Dir(path))
+}
+
+// syncDir fsyncs a directory so that a rename in it survives a crash.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// NextOffset returns the offset the next appended record will get, which
+// is also the number of records in the log.
+func (l *Log) NextOffset() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.next
+}
+
+// Segments describes the log's segment files, oldest first.
+func (l *Log) Segments() []Segment {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Segment(nil), l.segments...)
+}
+
+// Close closes the active segment. The log cannot be appended to after.
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active == nil {
+		return nil
+	}
+	err := l.active.Close()
+	l.active = nil
+	return err
+}
@@ -0,0 +1,168 @@
+package eventlog
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"my-solution/internal/events"
+)
+
+func appendN(t *testing.T, l *Log, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := l.Append(Record{Type: events.FavoriteAdded, UserID: "u1", AssetID: "a"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readAll(t *testing.T, l *Log, from uint64) []Record {
+	t.Helper()
+	var records []Record
+	if err := l.Read(from, func(r Record) error {
+		records = append(records, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestLog_SegmentsAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	l, err := Open(dir, Options{SegmentSize: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	appendN(t, l, 10)
+	if len(l.Segments()) < 2 {
+		t.Fatalf("expected the log to roll, got %+v", l.Segments())
+	}
+	l.Close()
+
+	l, err = Open(dir, Options{SegmentSize: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.NextOffset() != 10 {
+		t.Fatalf("expected next offset 10, got %d", l.NextOffset())
+	}
+	records := readAll(t, l, 7)
+	if len(records) != 3 || records[0].Offset != 7 {
+		t.Fatalf("unexpected records from 7: %+v", records)
+	}
+	r, _ := l.Append(Record{Type: events.FavoriteRemoved, UserID: "u1", AssetID: "a"})
+	if r.Offset != 10 {
+		t.Errorf("expected offset 10, got %d", r.Offset)
+	}
+}
+
+func TestLog_TornTailIsDiscarded(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := Open(dir, Options{})
+	appendN(t, l, 2)
+	path := l.Segments()[0].Path
+	l.Close()
+
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"offset":2,"type":"favor`)
+	f.Close()
+
+	l, err := Open(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	if l.NextOffset() != 2 {
+		t.Errorf("expected torn record dropped, next offset %d", l.NextOffset())
+	}
+	appendN(t, l, 1)
+	if records := readAll(t, l, 0); len(records) != 3 {
+		t.Errorf("expected 3 records, got %d", len(records))
+	}
+}
+
+func TestOpenReadOnly_LeavesFilesAlone(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := Open(dir, Options{})
+	appendN(t, l, 2)
+	path := l.Segments()[0].Path
+	l.Close()
+
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"offset":2,"type":"favor`)
+	f.Close()
+	before, _ := os.Stat(path)
+
+	ro, err := OpenReadOnly(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ro.Close()
+	if ro.NextOffset() != 2 {
+		t.Errorf("expected torn record skipped, next offset %d", ro.NextOffset())
+	}
+	if records := readAll(t, ro, 0); len(records) != 2 {
+		t.Errorf("expected 2 records, got %d", len(records))
+	}
+	if _, err := ro.Append(Record{Type: events.FavoriteAdded}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from Append, got %v", err)
+	}
+	if err := ro.Truncate(0); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from Truncate, got %v", err)
+	}
+	if after, _ := os.Stat(path); after.Size() != before.Size() {
+		t.Errorf("segment changed from %d to %d bytes", before.Size(), after.Size())
+	}
+
+	empty := t.TempDir()
+	if _, err := OpenReadOnly(empty); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(empty); len(entries) != 0 {
+		t.Errorf("expected no files created, got %d", len(entries))
+	}
+	if _, err := OpenReadOnly(empty + "/missing"); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestLog_CorruptRecord(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := Open(dir, Options{})
+	appendN(t, l, 1)
+	path := l.Segments()[0].Path
+	l.Close()
+
+	os.WriteFile(path, []byte("not json\n"), 0o644)
+	if _, err := Open(dir, Options{}); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("expected ErrCorrupt, got %v", err)
+	}
+}
+
+func TestLog_Truncate(t *testing.T) {
+	dir := t.TempDir()
+	l, _ := Open(dir, Options{SegmentSize: 256})
+	defer l.Close()
+	appendN(t, l, 10)
+
+	if err := l.Truncate(3); err != nil {
+		t.Fatal(err)
+	}
+	if l.NextOffset() != 3 || len(readAll(t, l, 0)) != 3 {
+		t.Fatalf("expected 3 records left, next offset %d", l.NextOffset())
+	}
+	appendN(t, l, 1)
+	if records := readAll(t, l, 0); records[3].Offset != 3 {
+		t.Errorf("appends should continue at the truncation point, got %+v", records[3])
+	}
+
+	if err := l.Truncate(0); err != nil {
+		t.Fatal(err)
+	}
+	if l.NextOffset() != 0 || len(l.Segments()) != 1 {
+		t.Errorf("expected an empty log, got %+v", l.Segments())
+	}
+}
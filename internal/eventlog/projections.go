@@ -0,0 +1,158 @@
+package eventlog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+)
+
+// Projection is a read model built from the log. Apply is called with
+// every record in offset order, first while replaying from offset zero and
+// then for each new record as it is appended.
+type Projection interface {
+	Apply(r Record)
+}
+
+// Popularity is a projection from asset ID to the users who favorited it.
+// It implements store.PopularityIndex.
+type Popularity struct {
+	mu    sync.Mutex
+	users map[string]map[string]time.Time // assetID -> userID -> CreatedAt
+}
+
+// NewPopularity returns an empty Popularity projection.
+func NewPopularity() *Popularity {
+	return &Popularity{users: make(map[string]map[string]time.Time)}
+}
+
+// Apply updates the index for added and removed favorites.
+func (p *Popularity) Apply(r Record) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch r.Type {
+	case events.FavoriteAdded:
+		if p.users[r.AssetID] == nil {
+			p.users[r.AssetID] = make(map[string]time.Time)
+		}
+		p.users[r.AssetID][r.UserID] = r.Time
+	case events.FavoriteRemoved:
+		delete(p.users[r.AssetID], r.UserID)
+		if len(p.users[r.AssetID]) == 0 {
+			delete(p.users, r.AssetID)
+		}
+	}
+}
+
+// FavoriteCounts returns the number of users who favorited each asset.
+func (p *Popularity) FavoriteCounts(ctx context.Context) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	counts := make(map[string]int, len(p.users))
+	for assetID, users := range p.users {
+		counts[assetID] = len(users)
+	}
+	return counts, nil
+}
+
+// Favoriters returns the IDs of users who favorited assetID.
+func (p *Popularity) Favoriters(ctx context.Context, assetID string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	users := make([]string, 0, len(p.users[assetID]))
+	for userID := range p.users[assetID] {
+		users = append(users, userID)
+	}
+	return users, nil
+}
+
+// FavoriteTimes returns, per asset, the CreatedAt of favorites created at
+// or after since.
+func (p *Popularity) FavoriteTimes(ctx context.Context, since time.Time) (map[string][]time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	result := make(map[string][]time.Time)
+	for assetID, users := range p.users {
+		for _, createdAt := range users {
+			if !createdAt.Before(since) {
+				result[assetID] = append(result[assetID], createdAt)
+			}
+		}
+	}
+	return result, nil
+}
+
+// TagIndex is a projection of each user's favorites by the #hashtags in
+// their descriptions.
+type TagIndex struct {
+	mu   sync.Mutex
+	tags map[string]map[string][]string // userID -> assetID -> tags
+}
+
+// NewTagIndex returns an empty TagIndex projection.
+func NewTagIndex() *TagIndex {
+	return &TagIndex{tags: make(map[string]map[string][]string)}
+}
+
+// Apply re-indexes the favorite a record touches.
+func (t *TagIndex) Apply(r Record) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch r.Type {
+	case events.FavoriteAdded, events.DescriptionEdited:
+		tags := models.Tags(r.Description)
+		if len(tags) == 0 {
+			t.remove(r.UserID, r.AssetID)
+			return
+		}
+		if t.tags[r.UserID] == nil {
+			t.tags[r.UserID] = make(map[string][]string)
+		}
+		t.tags[r.UserID][r.AssetID] = tags
+	case events.FavoriteRemoved:
+		t.remove(r.UserID, r.AssetID)
+	}
+}
+
+// remove drops a favorite from the index. Caller must hold t.mu.
+func (t *TagIndex) remove(userID, assetID string) {
+	delete(t.tags[userID], assetID)
+	if len(t.tags[userID]) == 0 {
+		delete(t.tags, userID)
+	}
+}
+
+// Tags returns, for each tag the user has used, the IDs of the favorited
+// assets carrying it, sorted.
+func (t *TagIndex) Tags(userID string) map[string][]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make(map[string][]string)
+	for assetID, tags := range t.tags[userID] {
+		for _, tag := range tags {
+			result[tag] = append(result[tag], assetID)
+		}
+	}
+	for _, assets := range result {
+		sort.Strings(assets)
+	}
+	return result
+}
@@ -0,0 +1,363 @@
+package eventlog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+// Store is a store.Store whose source of truth is a Log. Every write is
+// appended to the log before it is applied to the in-memory projection of
+// current favorites, which is rebuilt from the log when the store opens.
+//
+// It also implements store.PopularityIndex, store.UnavailableLister,
+// store.MembershipIndex and store.FavoriteScanner.
+type Store struct {
+	log *Log
+
+	// mu serialises writes so that validation against the projection and
+	// the append happen atomically, and guards the projections.
+	mu          sync.Mutex
+	users       map[string][]models.Favorite // userID -> favorites in list order
+	popularity  *Popularity
+	projections []Projection
+}
+
+// NewStore returns a store backed by l, rebuilding current state from it.
+func NewStore(l *Log) (*Store, error) {
+	s := &Store{
+		log:        l,
+		users:      make(map[string][]models.Favorite),
+		popularity: NewPopularity(),
+	}
+	err := l.Read(0, func(r Record) error {
+		s.apply(r)
+		s.popularity.Apply(r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.projections = []Projection{s.popularity}
+	return s, nil
+}
+
+// OpenStore opens the log in dir and returns a store backed by it.
+func OpenStore(dir string, opts Options) (*Store, error) {
+	l, err := Open(dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewStore(l)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Log returns the underlying log.
+func (s *Store) Log() *Log {
+	return s.log
+}
+
+// Close closes the underlying log.
+func (s *Store) Close() error {
+	return s.log.Close()
+}
+
+// Subscribe rebuilds p from offset zero and then keeps it up to date with
+// every record appended through s.
+func (s *Store) Subscribe(p Projection) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.log.Read(0, func(r Record) error {
+		p.Apply(r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.projections = append(s.projections, p)
+	return nil
+}
+
+// append writes a record to the log and applies it. Caller must hold s.mu.
+func (s *Store) append(r Record) error {
+	r.Time = time.Now()
+	r, err := s.log.Append(r)
+	if err != nil {
+		return err
+	}
+	s.apply(r)
+	for _, p := range s.projections {
+		p.Apply(r)
+	}
+	return nil
+}
+
+// apply updates the favorites projection. Caller must hold s.mu or have
+// exclusive access.
+func (s *Store) apply(r Record) {
+	favorites := s.users[r.UserID]
+	idx := -1
+	for i, fav := range favorites {
+		if fav.AssetID == r.AssetID {
+			idx = i
+			break
+		}
+	}
+
+	switch r.Type {
+	case events.FavoriteAdded:
+		if idx == -1 {
+			s.users[r.UserID] = append(favorites, models.Favorite{AssetID: r.AssetID, Description: r.Description, CreatedAt: r.Time})
+		}
+	case events.FavoriteRemoved:
+		if idx != -1 {
+			s.users[r.UserID] = append(favorites[:idx], favorites[idx+1:]...)
+		}
+	case events.DescriptionEdited:
+		if idx != -1 {
+			favorites[idx].Description = r.Description
+		}
+	}
+}
+
+// find returns the index of assetID in the user's favorites, or -1.
+// Caller must hold s.mu.
+func (s *Store) find(userID, assetID string) int {
+	for i, fav := range s.users[userID] {
+		if fav.AssetID == assetID {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddFavorite appends a FavoriteAdded record.
+func (s *Store) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(userID, assetID) != -1 {
+		return store.ErrAlreadyFavorited
+	}
+	return s.append(Record{Type: events.FavoriteAdded, UserID: userID, AssetID: assetID, Description: description})
+}
+
+// ListFavorites returns user's favorites with full asset data from catalog.
+func (s *Store) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.listFavorites(ctx, userID, false)
+}
+
+// ListFavoritesWithUnavailable is like ListFavorites but also returns
+// favorites whose asset is missing from the catalog, marked unavailable.
+func (s *Store) ListFavoritesWithUnavailable(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.listFavorites(ctx, userID, true)
+}
+
+func (s *Store) listFavorites(ctx context.Context, userID string, includeUnavailable bool) ([]models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	favorites := s.users[userID]
+	result := make([]models.FavoriteWithAsset, 0, len(favorites))
+	for _, fav := range favorites {
+		item := models.FavoriteWithAsset{
+			AssetID:     fav.AssetID,
+			Description: fav.Description,
+			CreatedAt:   fav.CreatedAt,
+		}
+		asset, ok := catalog.Global.Get(fav.AssetID)
+		if !ok {
+			if !includeUnavailable {
+				continue
+			}
+			item.Status = models.StatusUnavailable
+		}
+		item.Asset = asset
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// ScanFavorites calls fn with each of the user's favorites, reading them
+// from the projection in batches of store.ScanBatch.
+func (s *Store) ScanFavorites(ctx context.Context, userID string, fn func(models.FavoriteWithAsset) error) error {
+	lastID, lastIndex := "", -1
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		favorites := s.users[userID]
+		start := store.ResumeScan(favorites, lastID, lastIndex)
+		batch := append([]models.Favorite(nil), favorites[start:min(start+store.ScanBatch, len(favorites))]...)
+		s.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+		for _, fav := range batch {
+			asset, ok := catalog.Global.Get(fav.AssetID)
+			if !ok {
+				continue
+			}
+			item := models.FavoriteWithAsset{AssetID: fav.AssetID, Description: fav.Description, CreatedAt: fav.CreatedAt, Asset: asset}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		lastID, lastIndex = batch[len(batch)-1].AssetID, start+len(batch)-1
+	}
+}
+
+// GetFavorite returns one favorite from the projection.
+func (s *Store) GetFavorite(ctx context.Context, userID, assetID string) (models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return models.FavoriteWithAsset{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.find(userID, assetID)
+	if i == -1 {
+		return models.FavoriteWithAsset{}, store.ErrAssetNotFound
+	}
+	fav := s.users[userID][i]
+	item := models.FavoriteWithAsset{
+		AssetID:     fav.AssetID,
+		Description: fav.Description,
+		CreatedAt:   fav.CreatedAt,
+	}
+	asset, ok := catalog.Global.Get(fav.AssetID)
+	if !ok {
+		item.Status = models.StatusUnavailable
+	}
+	item.Asset = asset
+	return item, nil
+}
+
+// UpsertFavorite appends a FavoriteAdded record, or a DescriptionEdited
+// record if the asset is already favorited.
+func (s *Store) UpsertFavorite(ctx context.Context, userID, assetID, description string) (store.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := Record{Type: events.FavoriteAdded, UserID: userID, AssetID: assetID, Description: description}
+	result := store.Created
+	if s.find(userID, assetID) != -1 {
+		r.Type, result = events.DescriptionEdited, store.Updated
+	}
+	if err := s.append(r); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// RemoveFavorite appends a FavoriteRemoved record. Removing an asset that
+// is not favorited is not an error and appends nothing.
+func (s *Store) RemoveFavorite(ctx context.Context, userID, assetID string) (store.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(userID, assetID) == -1 {
+		return store.NotFound, nil
+	}
+	if err := s.append(Record{Type: events.FavoriteRemoved, UserID: userID, AssetID: assetID}); err != nil {
+		return 0, err
+	}
+	return store.Removed, nil
+}
+
+// EditFavoriteDescription appends a DescriptionEdited record.
+func (s *Store) EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.find(userID, assetID) == -1 {
+		return store.ErrAssetNotFound
+	}
+	return s.append(Record{Type: events.DescriptionEdited, UserID: userID, AssetID: assetID, Description: desc})
+}
+
+// FavoriteCounts returns the number of users who favorited each asset.
+func (s *Store) FavoriteCounts(ctx context.Context) (map[string]int, error) {
+	return s.popularity.FavoriteCounts(ctx)
+}
+
+// Favoriters returns the IDs of users who favorited assetID.
+func (s *Store) Favoriters(ctx context.Context, assetID string) ([]string, error) {
+	return s.popularity.Favoriters(ctx, assetID)
+}
+
+// FavoriteTimes returns, per asset, the CreatedAt of favorites created at
+// or after since.
+func (s *Store) FavoriteTimes(ctx context.Context, since time.Time) (map[string][]time.Time, error) {
+	return s.popularity.FavoriteTimes(ctx, since)
+}
+
+// EraseUser removes the user's favorites from every projection and
+// redacts their records from the log, so they do not come back when the
+// store is reopened.
+func (s *Store) EraseUser(ctx context.Context, userID string) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	favorites := s.users[userID]
+	for _, fav := range favorites {
+		// Not logged: the records they undo are about to be redacted.
+		r := Record{Type: events.FavoriteRemoved, UserID: userID, AssetID: fav.AssetID}
+		for _, p := range s.projections {
+			p.Apply(r)
+		}
+	}
+	delete(s.users, userID)
+
+	n, err := s.log.Redact(func(r Record) bool { return r.UserID == userID })
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int{"favorites": len(favorites), "logRecords": n}, nil
+}
+
+// FavoriteDescriptions returns the user's favorited asset IDs mapped to
+// their descriptions.
+func (s *Store) FavoriteDescriptions(ctx context.Context, userID string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]string, len(s.users[userID]))
+	for _, fav := range s.users[userID] {
+		result[fav.AssetID] = fav.Description
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package eventlog
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+	"my-solution/internal/store/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.Run(t, func() store.Store {
+		s, err := OpenStore(t.TempDir(), Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	})
+}
+
+func TestStore_RebuildsOnOpen(t *testing.T) {
+	catalog.Initialize()
+	catalog.Global.AddAsset("a", models.Chart{AssetBase: models.AssetBase{ID: "a", Name: "A"}, ChartType: "bar"})
+	catalog.Global.AddAsset("b", models.Chart{AssetBase: models.AssetBase{ID: "b", Name: "B"}, ChartType: "bar"})
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, _ := OpenStore(dir, Options{})
+	s.AddFavorite(ctx, "u1", "a", "first")
+	s.AddFavorite(ctx, "u1", "b", "")
+	s.AddFavorite(ctx, "u2", "a", "")
+	s.EditFavoriteDescription(ctx, "u1", "a", "edited")
+	s.RemoveFavorite(ctx, "u2", "a")
+	s.RemoveFavorite(ctx, "u2", "a") // No-op, not logged
+	before, _ := s.ListFavorites(ctx, "u1")
+	if s.Log().NextOffset() != 5 {
+		t.Errorf("expected 5 records, got %d", s.Log().NextOffset())
+	}
+	s.Close()
+
+	s, err := OpenStore(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	after, _ := s.ListFavorites(ctx, "u1")
+	if len(after) != 2 || after[0].Description != "edited" || !after[0].CreatedAt.Equal(before[0].CreatedAt) {
+		t.Fatalf("unexpected state after reopen %+v", after)
+	}
+	counts, _ := s.FavoriteCounts(ctx)
+	if counts["a"] != 1 || counts["b"] != 1 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+}
+
+func TestStore_SubscribeRebuildsFromZero(t *testing.T) {
+	ctx := context.Background()
+	s, _ := OpenStore(t.TempDir(), Options{})
+	defer s.Close()
+	s.AddFavorite(ctx, "u1", "a", "quarterly #Revenue #kpi")
+	s.AddFavorite(ctx, "u1", "b", "#kpi")
+
+	tags := NewTagIndex()
+	if err := s.Subscribe(tags); err != nil {
+		t.Fatal(err)
+	}
+	got := tags.Tags("u1")
+	if len(got["kpi"]) != 2 || len(got["revenue"]) != 1 {
+		t.Fatalf("replay should index existing records, got %v", got)
+	}
+
+	s.EditFavoriteDescription(ctx, "u1", "a", "no tags")
+	s.RemoveFavorite(ctx, "u1", "b")
+	if got := tags.Tags("u1"); len(got) != 0 {
+		t.Errorf("live records should update the index, got %v", got)
+	}
+}
+
+func TestStore_EraseUserRedactsLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, _ := OpenStore(dir, Options{})
+	s.AddFavorite(ctx, "u1", "a", "secret note")
+	s.AddFavorite(ctx, "u2", "a", "")
+
+	erased, err := s.EraseUser(ctx, "u1")
+	if err != nil || erased["logRecords"] != 1 {
+		t.Fatalf("erase: %v, %v", erased, err)
+	}
+	s.AddFavorite(ctx, "u2", "b", "")
+	s.Close()
+
+	data, _ := os.ReadFile(s.Log().Segments()[0].Path)
+	if bytes.Contains(data, []byte("u1")) || bytes.Contains(data, []byte("secret")) {
+		t.Errorf("log still holds erased data:\n%s", data)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(tmps) != 0 {
+		t.Errorf("redaction left temp files %v", tmps)
+	}
+
+	s, err = OpenStore(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if favs, _ := s.ListFavoritesWithUnavailable(ctx, "u1"); len(favs) != 0 {
+		t.Errorf("erased favorites came back: %+v", favs)
+	}
+	if counts, _ := s.FavoriteCounts(ctx); counts["a"] != 1 || s.Log().NextOffset() != 3 {
+		t.Errorf("unexpected counts %v, next offset %d", counts, s.Log().NextOffset())
+	}
+}
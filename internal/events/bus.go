@@ -0,0 +1,236 @@
+// Package events provides an in-process change feed of favorite events.
+//
+// Writes made through a Store decorated with NewStore are published to a
+// Bus. Each user has their own monotonically increasing sequence number and
+// a bounded replay buffer, so subscribers that reconnect can resume from the
+// last event they saw.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of change an Event describes.
+type Type string
+
+const (
+	FavoriteAdded     Type = "favorite.added"
+	FavoriteRemoved   Type = "favorite.removed"
+	DescriptionEdited Type = "favorite.description_edited"
+)
+
+const (
+	defaultReplaySize       = 256
+	defaultSubscriberBuffer = 64
+)
+
+// Event is a single change to a user's favorites.
+type Event struct {
+	Seq         uint64    `json:"seq"` // Per-user sequence number, starting at 1
+	Type        Type      `json:"type"`
+	UserID      string    `json:"userId"`
+	AssetID     string    `json:"assetId"`
+	Description string    `json:"description,omitempty"` // New description for added/edited events
+	Time        time.Time `json:"time"`
+
+	// Origin identifies the session that made the change (see WithOrigin),
+	// so that sessions can skip echoes of their own writes.
+	Origin string `json:"-"`
+
+	// Erasure marks FavoriteRemoved events published because the user's
+	// data was erased. They keep in-process read models in step but must
+	// not be sent outside the system.
+	Erasure bool `json:"-"`
+}
+
+// Bus fans out published events to subscribers and keeps a bounded
+// per-user replay buffer.
+type Bus struct {
+	mu         sync.Mutex
+	users      map[string]*userLog
+	listeners  []func(Event)
+	replaySize int
+	bufferSize int
+}
+
+// userLog holds the sequence counter, recent history and live subscribers
+// for one user.
+type userLog struct {
+	seq    uint64
+	replay []Event // Oldest first, at most replaySize entries
+	subs   map[*Subscription]struct{}
+}
+
+// Subscription is a live feed of one user's events.
+// Events is closed when the subscription is cancelled or when the
+// subscriber falls too far behind; in the latter case Dropped reports true
+// and the client is expected to resume from its last seen sequence.
+type Subscription struct {
+	Events <-chan Event
+
+	ch      chan Event
+	bus     *Bus
+	userID  string
+	dropped bool
+	closed  bool
+}
+
+// NewBus creates a bus that keeps up to replaySize events per user for
+// resumption. A non-positive replaySize selects the default.
+func NewBus(replaySize int) *Bus {
+	if replaySize <= 0 {
+		replaySize = defaultReplaySize
+	}
+	return &Bus{
+		users:      make(map[string]*userLog),
+		replaySize: replaySize,
+		bufferSize: defaultSubscriberBuffer,
+	}
+}
+
+func (b *Bus) log(userID string) *userLog {
+	l, ok := b.users[userID]
+	if !ok {
+		l = &userLog{subs: make(map[*Subscription]struct{})}
+		b.users[userID] = l
+	}
+	return l
+}
+
+// Publish assigns the next sequence number for e.UserID, records the event
+// in the replay buffer and delivers it to live subscribers. Subscribers
+// whose buffer is full are dropped rather than blocking the publisher.
+func (b *Bus) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.log(e.UserID)
+	l.seq++
+	e.Seq = l.seq
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	l.replay = append(l.replay, e)
+	if len(l.replay) > b.replaySize {
+		l.replay = l.replay[len(l.replay)-b.replaySize:]
+	}
+
+	for _, fn := range b.listeners {
+		fn(e)
+	}
+
+	for sub := range l.subs {
+		select {
+		case sub.ch <- e:
+		default:
+			sub.dropped = true
+			b.closeLocked(l, sub)
+		}
+	}
+	return e
+}
+
+// AddListener registers fn to be called synchronously for every event
+// published for any user, in publish order. Unlike subscriptions,
+// listeners are never dropped, so fn must return quickly (typically by
+// queueing the event) and must not call back into the bus.
+func (b *Bus) AddListener(fn func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.listeners = append(b.listeners, fn)
+}
+
+// Subscribe starts a live feed for userID. Events with a sequence number
+// greater than afterSeq that are still in the replay buffer are returned
+// as backlog; complete is false when some of them have already been
+// evicted and the caller must resynchronise from the store.
+func (b *Bus) Subscribe(userID string, afterSeq uint64) (sub *Subscription, backlog []Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	l := b.log(userID)
+	ch := make(chan Event, b.bufferSize)
+	sub = &Subscription{Events: ch, ch: ch, bus: b, userID: userID}
+	l.subs[sub] = struct{}{}
+
+	complete = true
+	if afterSeq > l.seq {
+		// The client is ahead of us (e.g. the server restarted); nothing to replay.
+		return sub, nil, false
+	}
+	if afterSeq < l.seq {
+		if len(l.replay) == 0 || l.replay[0].Seq > afterSeq+1 {
+			complete = false
+		}
+		for _, e := range l.replay {
+			if e.Seq > afterSeq {
+				backlog = append(backlog, e)
+			}
+		}
+	}
+	return sub, backlog, complete
+}
+
+// LastSeq returns the most recent sequence number published for userID.
+func (b *Bus) LastSeq(userID string) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if l, ok := b.users[userID]; ok {
+		return l.seq
+	}
+	return 0
+}
+
+// Cancel stops the subscription and closes its Events channel.
+// It is safe to call more than once.
+func (s *Subscription) Cancel() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	if l, ok := s.bus.users[s.userID]; ok {
+		s.bus.closeLocked(l, s)
+	}
+}
+
+// Dropped reports whether the subscription was closed because the
+// subscriber could not keep up.
+func (s *Subscription) Dropped() bool {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.dropped
+}
+
+func (b *Bus) closeLocked(l *userLog, s *Subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	delete(l.subs, s)
+	close(s.ch)
+}
+
+// Recent returns the events still in userID's replay buffer, oldest first.
+func (b *Bus) Recent(userID string) []Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if l, ok := b.users[userID]; ok {
+		return append([]Event(nil), l.replay...)
+	}
+	return nil
+}
+
+// Forget drops userID's replay buffer and returns how many events it held.
+// Live subscriptions and the sequence counter are kept so that connected
+// clients are not confused by sequence numbers going backwards.
+func (b *Bus) Forget(userID string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l, ok := b.users[userID]
+	if !ok {
+		return 0
+	}
+	n := len(l.replay)
+	l.replay = nil
+	return n
+}
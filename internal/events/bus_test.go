@@ -0,0 +1,157 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"my-solution/internal/store"
+	"my-solution/internal/store/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.Run(t, func() store.Store { return NewStore(store.NewMemoryStore(), NewBus(0)) })
+}
+
+func TestBus_PerUserSequence(t *testing.T) {
+	bus := NewBus(0)
+	a1 := bus.Publish(Event{Type: FavoriteAdded, UserID: "a", AssetID: "x"})
+	b1 := bus.Publish(Event{Type: FavoriteAdded, UserID: "b", AssetID: "x"})
+	a2 := bus.Publish(Event{Type: FavoriteRemoved, UserID: "a", AssetID: "x"})
+
+	if a1.Seq != 1 || a2.Seq != 2 || b1.Seq != 1 {
+		t.Errorf("unexpected sequences: a1=%d a2=%d b1=%d", a1.Seq, a2.Seq, b1.Seq)
+	}
+	if a1.Time.IsZero() {
+		t.Error("expected publish to stamp the event time")
+	}
+}
+
+func TestBus_SubscribeReceivesLiveEvents(t *testing.T) {
+	bus := NewBus(0)
+	sub, backlog, complete := bus.Subscribe("u", 0)
+	defer sub.Cancel()
+	if len(backlog) != 0 || !complete {
+		t.Fatalf("expected empty complete backlog, got %d complete=%v", len(backlog), complete)
+	}
+
+	bus.Publish(Event{Type: FavoriteAdded, UserID: "u", AssetID: "x"})
+	bus.Publish(Event{Type: FavoriteAdded, UserID: "other", AssetID: "y"})
+
+	e := <-sub.Events
+	if e.AssetID != "x" || e.Seq != 1 {
+		t.Errorf("unexpected event %+v", e)
+	}
+	select {
+	case e := <-sub.Events:
+		t.Errorf("received another user's event: %+v", e)
+	default:
+	}
+}
+
+func TestBus_ResumeFromReplayBuffer(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: FavoriteAdded, UserID: "u"})
+	}
+
+	// Seq 3..5 are buffered; resuming after 2 is lossless.
+	sub, backlog, complete := bus.Subscribe("u", 2)
+	sub.Cancel()
+	if !complete || len(backlog) != 3 || backlog[0].Seq != 3 {
+		t.Errorf("resume after 2: complete=%v backlog=%v", complete, backlog)
+	}
+
+	// Seq 2 has been evicted; resuming after 1 is lossy.
+	sub, backlog, complete = bus.Subscribe("u", 1)
+	sub.Cancel()
+	if complete || len(backlog) != 3 {
+		t.Errorf("resume after 1: complete=%v backlog=%d", complete, len(backlog))
+	}
+
+	// Already up to date.
+	sub, backlog, complete = bus.Subscribe("u", 5)
+	sub.Cancel()
+	if !complete || len(backlog) != 0 {
+		t.Errorf("resume after 5: complete=%v backlog=%d", complete, len(backlog))
+	}
+}
+
+func TestBus_SlowSubscriberIsDropped(t *testing.T) {
+	bus := NewBus(0)
+	sub, _, _ := bus.Subscribe("u", 0)
+	for i := 0; i < defaultSubscriberBuffer+1; i++ {
+		bus.Publish(Event{Type: FavoriteAdded, UserID: "u"})
+	}
+	if !sub.Dropped() {
+		t.Fatal("expected slow subscriber to be dropped")
+	}
+	n := 0
+	for range sub.Events {
+		n++
+	}
+	if n != defaultSubscriberBuffer {
+		t.Errorf("expected %d buffered events before close, got %d", defaultSubscriberBuffer, n)
+	}
+	sub.Cancel() // must not panic after drop
+}
+
+func TestStore_PublishesWrites(t *testing.T) {
+	ctx := context.Background()
+	bus := NewBus(0)
+	s := NewStore(store.NewMemoryStore(), bus)
+	sub, _, _ := bus.Subscribe("u", 0)
+	defer sub.Cancel()
+
+	if err := s.AddFavorite(ctx, "u", "a1", "d"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddFavorite(ctx, "u", "a1", "d"); err == nil {
+		t.Fatal("expected duplicate add to fail")
+	}
+	if err := s.EditFavoriteDescription(ctx, "u", "a1", "new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.EditFavoriteDescription(ctx, "u", "missing", "new"); err == nil {
+		t.Fatal("expected edit of missing favorite to fail")
+	}
+	if _, err := s.RemoveFavorite(ctx, "u", "a1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Type{FavoriteAdded, DescriptionEdited, FavoriteRemoved}
+	for i, typ := range want {
+		e := <-sub.Events
+		if e.Type != typ || e.Seq != uint64(i+1) {
+			t.Errorf("event %d: want %s seq %d, got %s seq %d", i, typ, i+1, e.Type, e.Seq)
+		}
+	}
+	if _, err := s.RemoveFavorite(ctx, "u", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if bus.LastSeq("u") != 3 {
+		t.Errorf("failed and no-op writes must not publish; last seq %d", bus.LastSeq("u"))
+	}
+}
+
+func TestStore_UnwrapExposesPopularityIndex(t *testing.T) {
+	s := NewStore(store.NewMemoryStore(), NewBus(0))
+	if _, ok := store.Find[store.PopularityIndex](s); !ok {
+		t.Error("expected popularity index to be reachable through the decorator")
+	}
+}
+
+func TestStore_EraseUserMarksEvents(t *testing.T) {
+	ctx := context.Background()
+	bus := NewBus(0)
+	s := NewStore(store.NewMemoryStore(), bus)
+	var got []Event
+	bus.AddListener(func(e Event) { got = append(got, e) })
+
+	s.AddFavorite(ctx, "u", "a1", "")
+	if _, err := s.EraseUser(ctx, "u"); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Erasure || got[1].Type != FavoriteRemoved || !got[1].Erasure {
+		t.Errorf("expected only the erasure removal to be marked, got %+v", got)
+	}
+}
@@ -0,0 +1,215 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+type originKey struct{}
+
+// WithOrigin tags writes made with ctx as coming from origin. Events
+// published for those writes carry it in Event.Origin.
+func WithOrigin(ctx context.Context, origin string) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+func originFrom(ctx context.Context) string {
+	origin, _ := ctx.Value(originKey{}).(string)
+	return origin
+}
+
+// Store decorates a store.Store so that every successful write is
+// published to a Bus.
+type Store struct {
+	store.Store
+	bus *Bus
+
+	// mu serialises writes so that events are published in the order the
+	// underlying store applied them.
+	mu sync.Mutex
+}
+
+// NewStore wraps s so that writes are published to bus.
+func NewStore(s store.Store, bus *Bus) *Store {
+	return &Store{Store: s, bus: bus}
+}
+
+// AddFavorite adds the favorite and publishes FavoriteAdded.
+func (s *Store) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.Store.AddFavorite(ctx, userID, assetID, description); err != nil {
+		return err
+	}
+	s.bus.Publish(Event{Type: FavoriteAdded, UserID: userID, AssetID: assetID, Description: description, Origin: originFrom(ctx)})
+	return nil
+}
+
+// ListFavorites is passed through unchanged.
+func (s *Store) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.Store.ListFavorites(ctx, userID)
+}
+
+// UpsertFavorite adds or updates the favorite and publishes FavoriteAdded
+// or DescriptionEdited accordingly.
+func (s *Store) UpsertFavorite(ctx context.Context, userID, assetID, description string) (store.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.Store.UpsertFavorite(ctx, userID, assetID, description)
+	if err != nil {
+		return 0, err
+	}
+	e := Event{Type: FavoriteAdded, UserID: userID, AssetID: assetID, Description: description, Origin: originFrom(ctx)}
+	if result == store.Updated {
+		e.Type = DescriptionEdited
+	}
+	s.bus.Publish(e)
+	return result, nil
+}
+
+// RemoveFavorite removes the favorite and publishes FavoriteRemoved.
+// Nothing is published if the asset was not favorited.
+func (s *Store) RemoveFavorite(ctx context.Context, userID, assetID string) (store.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.Store.RemoveFavorite(ctx, userID, assetID)
+	if err != nil {
+		return 0, err
+	}
+	if result != store.Removed {
+		return result, nil
+	}
+	s.bus.Publish(Event{Type: FavoriteRemoved, UserID: userID, AssetID: assetID, Origin: originFrom(ctx)})
+	return result, nil
+}
+
+// EditFavoriteDescription updates the description and publishes
+// DescriptionEdited.
+func (s *Store) EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.Store.EditFavoriteDescription(ctx, userID, assetID, desc); err != nil {
+		return err
+	}
+	s.bus.Publish(Event{Type: DescriptionEdited, UserID: userID, AssetID: assetID, Description: desc, Origin: originFrom(ctx)})
+	return nil
+}
+
+// Unwrap returns the decorated store.
+func (s *Store) Unwrap() store.Store {
+	return s.Store
+}
+
+// Forwards reports that Trash, UserEraser and AssetMigrator are only
+// available when the decorated store provides them.
+func (s *Store) Forwards(capability any) bool {
+	switch capability.(type) {
+	case *store.Trash, *store.UserEraser, *store.AssetMigrator:
+		return true
+	}
+	return false
+}
+
+// ListTrash is forwarded to the underlying store.Trash.
+func (s *Store) ListTrash(ctx context.Context, userID string) ([]models.TrashedFavorite, error) {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+	return t.ListTrash(ctx, userID)
+}
+
+// RestoreFavorite restores the favorite and publishes FavoriteAdded.
+func (s *Store) RestoreFavorite(ctx context.Context, userID, assetID string) error {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := t.RestoreFavorite(ctx, userID, assetID); err != nil {
+		return err
+	}
+	e := Event{Type: FavoriteAdded, UserID: userID, AssetID: assetID, Origin: originFrom(ctx)}
+	if fav, err := s.Store.GetFavorite(ctx, userID, assetID); err == nil {
+		e.Description = fav.Description
+	}
+	s.bus.Publish(e)
+	return nil
+}
+
+// PurgeTrash is forwarded to the underlying store.Trash.
+func (s *Store) PurgeTrash(ctx context.Context, now time.Time) (int, error) {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return 0, store.ErrUnsupported
+	}
+	return t.PurgeTrash(ctx, now)
+}
+
+// EraseUser erases the user's data from the underlying store, publishes
+// FavoriteRemoved for each erased favorite so that listeners drop it too,
+// and then forgets the user's replay buffer. The events are marked with
+// Erasure so that they are not forwarded outside the system.
+func (s *Store) EraseUser(ctx context.Context, userID string) (map[string]int, error) {
+	eraser, ok := store.Find[store.UserEraser](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := s.Store.ListFavorites
+	if l, ok := store.Find[store.UnavailableLister](s.Store); ok {
+		list = l.ListFavoritesWithUnavailable
+	}
+	favs, err := list(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	erased, err := eraser.EraseUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fav := range favs {
+		s.bus.Publish(Event{Type: FavoriteRemoved, UserID: userID, AssetID: fav.AssetID, Origin: originFrom(ctx), Erasure: true})
+	}
+	erased["events"] = s.bus.Forget(userID)
+	return erased, nil
+}
+
+// MigrateAsset migrates favorites in the underlying store and publishes,
+// for each affected user, FavoriteRemoved for oldID and, unless the
+// favorite was merged into an existing one, FavoriteAdded for newID.
+func (s *Store) MigrateAsset(ctx context.Context, oldID, newID string) ([]store.MigratedFavorite, error) {
+	migrator, ok := store.Find[store.AssetMigrator](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	moved, err := migrator.MigrateAsset(ctx, oldID, newID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range moved {
+		s.bus.Publish(Event{Type: FavoriteRemoved, UserID: m.UserID, AssetID: oldID, Origin: originFrom(ctx)})
+		if !m.Merged {
+			s.bus.Publish(Event{Type: FavoriteAdded, UserID: m.UserID, AssetID: newID, Description: m.Description, Origin: originFrom(ctx)})
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,200 @@
+// Package export defines the file formats favorites are exported to and
+// imported from: CSV, a JSON array, or newline-delimited JSON, with one
+// row per favorite.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"my-solution/internal/models"
+)
+
+// ErrUnknownFormat is returned by ParseFormat for unsupported formats.
+var ErrUnknownFormat = errors.New("format must be csv, json or ndjson")
+
+// Format is an export file format.
+type Format string
+
+const (
+	CSV    Format = "csv"
+	JSON   Format = "json"
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case CSV, JSON, NDJSON:
+		return f, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// ContentType returns the MIME type of files in format f.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case NDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/json"
+}
+
+// Row is one exported favorite: the user's metadata plus the asset's type,
+// name and type-specific fields.
+type Row struct {
+	AssetID     string    `json:"assetId"`
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"` // The user's description, not the asset's
+	CreatedAt   time.Time `json:"createdAt"`
+
+	ChartType string `json:"chartType,omitempty"`
+	Metric    string `json:"metric,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Segment   string `json:"segment,omitempty"`
+	Size      *int   `json:"size,omitempty"`
+}
+
+// columns is the CSV header, in Row field order.
+var columns = []string{"assetId", "type", "name", "description", "createdAt", "chartType", "metric", "value", "segment", "size"}
+
+// FromFavorite builds the row for fav. Favorites whose asset has left the
+// catalog only carry the user's fields.
+func FromFavorite(fav models.FavoriteWithAsset) Row {
+	row := Row{
+		AssetID:     fav.AssetID,
+		Description: fav.Description,
+		CreatedAt:   fav.CreatedAt,
+	}
+	if fav.Asset == nil {
+		return row
+	}
+	row.Type = models.AssetType(fav.Asset)
+	row.Name = fav.Asset.GetName()
+	switch a := fav.Asset.(type) {
+	case models.Chart:
+		row.ChartType = a.ChartType
+	case *models.Chart:
+		row.ChartType = a.ChartType
+	case models.Insight:
+		row.Metric, row.Value = a.Metric, a.Value
+	case *models.Insight:
+		row.Metric, row.Value = a.Metric, a.Value
+	case models.Audience:
+		row.Segment, row.Size = a.Segment, &a.Size
+	case *models.Audience:
+		size := a.Size
+		row.Segment, row.Size = a.Segment, &size
+	}
+	return row
+}
+
+// Writer encodes rows to an underlying writer as they are written.
+type Writer struct {
+	w      io.Writer
+	format Format
+	csv    *csv.Writer
+	rows   int
+}
+
+// NewWriter returns a Writer encoding rows in format f to w.
+func NewWriter(w io.Writer, f Format) *Writer {
+	ew := &Writer{w: w, format: f}
+	if f == CSV {
+		ew.csv = csv.NewWriter(w)
+	}
+	return ew
+}
+
+// Write encodes one row.
+func (w *Writer) Write(r Row) error {
+	defer func() { w.rows++ }()
+
+	switch w.format {
+	case CSV:
+		if w.rows == 0 {
+			if err := w.csv.Write(columns); err != nil {
+				return err
+			}
+		}
+		size := ""
+		if r.Size != nil {
+			size = strconv.Itoa(*r.Size)
+		}
+		return w.csv.Write([]string{
+			escapeCell(r.AssetID), escapeCell(r.Type), escapeCell(r.Name), escapeCell(r.Description),
+			r.CreatedAt.Format(time.RFC3339Nano),
+			escapeCell(r.ChartType), escapeCell(r.Metric), escapeCell(r.Value), escapeCell(r.Segment), size,
+		})
+	}
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if w.format == JSON {
+		sep := ",\n"
+		if w.rows == 0 {
+			sep = "[\n"
+		}
+		line = append([]byte(sep), line...)
+	} else {
+		line = append(line, '\n')
+	}
+	_, err = w.w.Write(line)
+	return err
+}
+
+// escapeCell prefixes CSV text cells that a spreadsheet would run as a
+// formula with a quote, which spreadsheets hide. Cells already starting
+// with a quote get another one, so that unescapeCell restores any value.
+func escapeCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r'", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// unescapeCell reverses escapeCell.
+func unescapeCell(s string) string {
+	return strings.TrimPrefix(s, "'")
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// Close finishes the file, e.g. the closing bracket of a JSON array or
+// the header of an empty CSV file, and flushes it.
+func (w *Writer) Close() error {
+	switch w.format {
+	case CSV:
+		if w.rows == 0 {
+			if err := w.csv.Write(columns); err != nil {
+				return err
+			}
+		}
+	case JSON:
+		end := "\n]\n"
+		if w.rows == 0 {
+			end = "[]\n"
+		}
+		if _, err := io.WriteString(w.w, end); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
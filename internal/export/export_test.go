@@ -0,0 +1,144 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"my-solution/internal/models"
+)
+
+var testCreated = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testFavorites() []models.FavoriteWithAsset {
+	return []models.FavoriteWithAsset{
+		{AssetID: "c1", Description: "sales, q1", CreatedAt: testCreated, Asset: models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Sales"}, ChartType: "bar"}},
+		{AssetID: "i1", CreatedAt: testCreated, Asset: models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "Engagement"}, Metric: "hours", Value: "3+"}},
+		{AssetID: "a1", CreatedAt: testCreated, Asset: models.Audience{AssetBase: models.AssetBase{ID: "a1", Name: "Empty"}, Segment: "none", Size: 0}},
+	}
+}
+
+func encode(t *testing.T, f Format, favs []models.FavoriteWithAsset) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf, f)
+	for _, fav := range favs {
+		if err := w.Write(FromFavorite(fav)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestWriter_CSV(t *testing.T) {
+	got := encode(t, CSV, testFavorites())
+	want := "assetId,type,name,description,createdAt,chartType,metric,value,segment,size\n" +
+		"c1,chart,Sales,\"sales, q1\",2024-03-01T12:00:00Z,bar,,,,\n" +
+		"i1,insight,Engagement,,2024-03-01T12:00:00Z,,hours,3+,,\n" +
+		"a1,audience,Empty,,2024-03-01T12:00:00Z,,,,none,0\n"
+	if got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if got := encode(t, CSV, nil); !strings.HasPrefix(got, "assetId,") {
+		t.Errorf("empty export should still have a header, got %q", got)
+	}
+}
+
+func TestWriter_CSVEscapesFormulas(t *testing.T) {
+	descriptions := []string{"=HYPERLINK(\"x\")", "+1", "-1", "@SUM(A1)", "'quoted", "plain"}
+	favs := make([]models.FavoriteWithAsset, len(descriptions))
+	for i, d := range descriptions {
+		favs[i] = models.FavoriteWithAsset{AssetID: "c1", Description: d, CreatedAt: testCreated}
+	}
+	out := encode(t, CSV, favs)
+	for _, cell := range []string{`"'=HYPERLINK(""x"")"`, ",'+1,", ",'-1,", ",'@SUM(A1),", ",''quoted,", ",plain,"} {
+		if !strings.Contains(out, cell) {
+			t.Errorf("expected cell %s in\n%s", cell, out)
+		}
+	}
+
+	rd := NewReader(strings.NewReader(out), CSV)
+	for _, want := range descriptions {
+		row, err := rd.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if row.Description != want {
+			t.Errorf("got description %q, want %q", row.Description, want)
+		}
+	}
+}
+
+func TestWriter_JSON(t *testing.T) {
+	var rows []Row
+	if err := json.Unmarshal([]byte(encode(t, JSON, testFavorites())), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[2].Size == nil || *rows[2].Size != 0 || rows[1].Metric != "hours" {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+	if got := encode(t, JSON, nil); got != "[]\n" {
+		t.Errorf("expected empty array, got %q", got)
+	}
+}
+
+func TestWriter_NDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(encode(t, NDJSON, testFavorites())), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	var row Row
+	if err := json.Unmarshal([]byte(lines[0]), &row); err != nil || row.ChartType != "bar" || row.Description != "sales, q1" {
+		t.Errorf("unexpected row %+v, %v", row, err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if _, err := ParseFormat("xml"); err != ErrUnknownFormat {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestReader_RoundTrip(t *testing.T) {
+	for _, f := range []Format{CSV, JSON, NDJSON} {
+		rd := NewReader(strings.NewReader(encode(t, f, testFavorites())), f)
+		var rows []Row
+		for {
+			row, err := rd.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", f, err)
+			}
+			rows = append(rows, row)
+		}
+		if len(rows) != 3 || rows[0].Description != "sales, q1" || !rows[0].CreatedAt.Equal(testCreated) || rows[2].Size == nil || *rows[2].Size != 0 {
+			t.Errorf("%s: unexpected rows %+v", f, rows)
+		}
+	}
+}
+
+func TestReader_Invalid(t *testing.T) {
+	cases := map[Format]string{
+		CSV:    "name,description\nx,y\n",
+		JSON:   `{"assetId":"x"}`,
+		NDJSON: "{\"assetId\":\"x\"}\nnot json\n",
+	}
+	for f, input := range cases {
+		rd := NewReader(strings.NewReader(input), f)
+		var err error
+		for err == nil {
+			_, err = rd.Next()
+		}
+		if err == io.EOF {
+			t.Errorf("%s: expected a decode error", f)
+		}
+	}
+}
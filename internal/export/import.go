@@ -0,0 +1,224 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/store"
+)
+
+var (
+	// ErrConflict is returned by Import under PolicyFail when a row is
+	// already among the user's favorites.
+	ErrConflict = errors.New("import conflicts with existing favorites")
+
+	// ErrInvalidFile is returned by Import when the file cannot be decoded.
+	ErrInvalidFile = errors.New("invalid import file")
+
+	// ErrUnknownPolicy is returned by ParsePolicy for unsupported policies.
+	ErrUnknownPolicy = errors.New("policy must be skip, overwrite or fail")
+)
+
+// MaxImportRows bounds the number of rows a single import may contain.
+const MaxImportRows = 10000
+
+// Policy decides what Import does with rows for assets the user has
+// already favorited.
+type Policy string
+
+const (
+	PolicySkip      Policy = "skip"      // Leave the existing favorite alone
+	PolicyOverwrite Policy = "overwrite" // Replace its description
+	PolicyFail      Policy = "fail"      // Abort the import without writing
+)
+
+// ParsePolicy returns the Policy named s.
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(s); p {
+	case PolicySkip, PolicyOverwrite, PolicyFail:
+		return p, nil
+	}
+	return "", ErrUnknownPolicy
+}
+
+// Action is what Import did, or would do, with one row.
+type Action string
+
+const (
+	ActionAdd       Action = "add"
+	ActionOverwrite Action = "overwrite"
+	ActionSkip      Action = "skip"     // Conflict left alone, or description unchanged
+	ActionConflict  Action = "conflict" // Conflict under PolicyFail
+	ActionInvalid   Action = "invalid"  // Asset not in the catalog or row malformed
+)
+
+// RowResult reports the outcome of one imported row.
+type RowResult struct {
+	Row     int    `json:"row"` // 1-based position in the file
+	AssetID string `json:"assetId"`
+	Action  Action `json:"action"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report summarises an import.
+type Report struct {
+	DryRun      bool        `json:"dryRun"`
+	Policy      Policy      `json:"policy"`
+	Added       int         `json:"added"`
+	Overwritten int         `json:"overwritten"`
+	Skipped     int         `json:"skipped"`
+	Conflicts   int         `json:"conflicts"`
+	Invalid     int         `json:"invalid"`
+	Rows        []RowResult `json:"rows"`
+}
+
+// ImportOptions configures Import.
+type ImportOptions struct {
+	Policy Policy
+	DryRun bool // Report what would change without writing
+}
+
+// Import reads every row from rd and adds it to the user's favorites
+// through s.AddFavorite, or s.UpsertFavorite under PolicyOverwrite, so
+// decorators such as history and events see ordinary writes. Rows whose
+// asset ID is not in catalog.Global are reported as invalid and skipped;
+// IDs of replaced assets are resolved to their replacement. CreatedAt in
+// the file is ignored.
+//
+// The whole file is read and planned before anything is written, so a
+// malformed file or a conflict found while planning leaves the store
+// untouched. A favorite added concurrently after planning is handled by
+// the policy when its row is written: skipped, overwritten, or under
+// PolicyFail reported as a conflict that stops the import with
+// ErrConflict, leaving the rows before it written. The report always
+// says what was done with each row.
+func Import(ctx context.Context, s store.Store, userID string, rd *Reader, opts ImportOptions) (Report, error) {
+	if opts.Policy == "" {
+		opts.Policy = PolicySkip
+	}
+	report := Report{DryRun: opts.DryRun, Policy: opts.Policy, Rows: make([]RowResult, 0)}
+
+	existing, err := s.ListFavorites(ctx, userID)
+	if err != nil {
+		return report, err
+	}
+	current := make(map[string]string, len(existing)) // assetID -> description
+	for _, fav := range existing {
+		current[fav.AssetID] = fav.Description
+	}
+
+	type write struct {
+		row                  int // Index in report.Rows
+		assetID, description string
+	}
+	var plan []write
+
+	for n := 1; ; n++ {
+		row, err := rd.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return report, fmt.Errorf("%w: row %d: %w", ErrInvalidFile, n, err)
+		}
+		if n > MaxImportRows {
+			return report, fmt.Errorf("%w: limited to %d rows", ErrInvalidFile, MaxImportRows)
+		}
+
+		result := RowResult{Row: n, AssetID: row.AssetID}
+		assetID, ok := catalog.Global.Resolve(row.AssetID)
+		switch {
+		case row.AssetID == "":
+			result.Action, result.Error = ActionInvalid, "assetId is required"
+		case !ok:
+			result.Action, result.Error = ActionInvalid, "asset not found in catalog"
+		default:
+			desc, exists := current[assetID]
+			switch {
+			case !exists:
+				result.Action = ActionAdd
+			case opts.Policy == PolicyFail:
+				result.Action = ActionConflict
+			case opts.Policy == PolicyOverwrite && desc != row.Description:
+				result.Action = ActionOverwrite
+			default:
+				result.Action = ActionSkip
+			}
+			if result.Action == ActionAdd || result.Action == ActionOverwrite {
+				plan = append(plan, write{row: len(report.Rows), assetID: assetID, description: row.Description})
+				// Later rows for the same asset conflict with this one.
+				current[assetID] = row.Description
+			}
+		}
+		report.count(result.Action)
+		report.Rows = append(report.Rows, result)
+	}
+
+	if report.Conflicts > 0 {
+		return report, ErrConflict
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+
+	for i, w := range plan {
+		if opts.Policy == PolicyOverwrite {
+			result, err := s.UpsertFavorite(ctx, userID, w.assetID, w.description)
+			if err != nil {
+				return report, fmt.Errorf("%s %s: %w", report.Rows[w.row].Action, w.assetID, err)
+			}
+			if result == store.Created {
+				report.recount(w.row, ActionAdd, "")
+			} else {
+				report.recount(w.row, ActionOverwrite, "")
+			}
+			continue
+		}
+
+		err := s.AddFavorite(ctx, userID, w.assetID, w.description)
+		switch {
+		case err == nil:
+		case !errors.Is(err, store.ErrAlreadyFavorited):
+			return report, fmt.Errorf("%s %s: %w", ActionAdd, w.assetID, err)
+		case opts.Policy == PolicySkip:
+			report.recount(w.row, ActionSkip, "")
+		default:
+			report.recount(w.row, ActionConflict, err.Error())
+			for _, rest := range plan[i+1:] {
+				report.recount(rest.row, ActionSkip, "not written after conflict")
+			}
+			return report, ErrConflict
+		}
+	}
+	return report, nil
+}
+
+// recount changes the action reported for Rows[i] to a.
+func (r *Report) recount(i int, a Action, msg string) {
+	switch r.Rows[i].Action {
+	case ActionAdd:
+		r.Added--
+	case ActionOverwrite:
+		r.Overwritten--
+	}
+	r.Rows[i].Action, r.Rows[i].Error = a, msg
+	r.count(a)
+}
+
+func (r *Report) count(a Action) {
+	switch a {
+	case ActionAdd:
+		r.Added++
+	case ActionOverwrite:
+		r.Overwritten++
+	case ActionSkip:
+		r.Skipped++
+	case ActionConflict:
+		r.Conflicts++
+	case ActionInvalid:
+		r.Invalid++
+	}
+}
@@ -0,0 +1,123 @@
+package export
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+func seedImportCatalog() {
+	catalog.Initialize()
+	for _, id := range []string{"a", "b", "c"} {
+		catalog.Global.AddAsset(id, models.Chart{AssetBase: models.AssetBase{ID: id, Name: id}, ChartType: "bar"})
+	}
+	catalog.Global.AddAlias("old-c", "c")
+}
+
+const importFile = `{"assetId":"a","description":"new a"}
+{"assetId":"b","description":"b"}
+{"assetId":"old-c","description":"via alias"}
+{"assetId":"missing"}
+`
+
+func runImport(t *testing.T, s store.Store, opts ImportOptions) (Report, error) {
+	t.Helper()
+	return Import(context.Background(), s, "u1", NewReader(strings.NewReader(importFile), NDJSON), opts)
+}
+
+func TestImport_Policies(t *testing.T) {
+	seedImportCatalog()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		policy Policy
+		want   string // Description of a afterwards
+	}{
+		{PolicySkip, "old a"},
+		{PolicyOverwrite, "new a"},
+	} {
+		s := store.NewMemoryStore()
+		s.AddFavorite(ctx, "u1", "a", "old a")
+		report, err := runImport(t, s, ImportOptions{Policy: tc.policy})
+		if err != nil {
+			t.Fatalf("%s: %v", tc.policy, err)
+		}
+		if report.Added != 2 || report.Invalid != 1 || report.Skipped+report.Overwritten != 1 {
+			t.Errorf("%s: unexpected report %+v", tc.policy, report)
+		}
+		favs, _ := s.ListFavorites(ctx, "u1")
+		if len(favs) != 3 || favs[0].Description != tc.want || favs[2].AssetID != "c" {
+			t.Errorf("%s: unexpected favorites %+v", tc.policy, favs)
+		}
+	}
+}
+
+func TestImport_FailAndDryRunWriteNothing(t *testing.T) {
+	seedImportCatalog()
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "a", "old a")
+
+	report, err := runImport(t, s, ImportOptions{Policy: PolicyFail})
+	if err != ErrConflict || report.Conflicts != 1 || report.Rows[0].Action != ActionConflict {
+		t.Errorf("expected a conflict, got %+v, %v", report, err)
+	}
+
+	report, err = runImport(t, s, ImportOptions{Policy: PolicyOverwrite, DryRun: true})
+	if err != nil || !report.DryRun || report.Added != 2 || report.Overwritten != 1 {
+		t.Errorf("unexpected dry run %+v, %v", report, err)
+	}
+
+	if favs, _ := s.ListFavorites(ctx, "u1"); len(favs) != 1 || favs[0].Description != "old a" {
+		t.Errorf("store should be untouched, got %+v", favs)
+	}
+}
+
+// racingStore adds a favorite right after Import has listed the user's
+// favorites, as a concurrent request would.
+type racingStore struct {
+	*store.MemoryStore
+	assetID string
+}
+
+func (s racingStore) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	favs, err := s.MemoryStore.ListFavorites(ctx, userID)
+	s.MemoryStore.AddFavorite(ctx, userID, s.assetID, "concurrent")
+	return favs, err
+}
+
+func TestImport_ConflictAfterPlanning(t *testing.T) {
+	seedImportCatalog()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		policy  Policy
+		want    Action // Reported for row 2, which conflicts at write time
+		wantB   string // Description of b afterwards
+		wantErr error
+	}{
+		{PolicySkip, ActionSkip, "concurrent", nil},
+		{PolicyOverwrite, ActionOverwrite, "b", nil},
+		{PolicyFail, ActionConflict, "concurrent", ErrConflict},
+	} {
+		s := racingStore{MemoryStore: store.NewMemoryStore(), assetID: "b"}
+		report, err := runImport(t, s, ImportOptions{Policy: tc.policy})
+		if err != tc.wantErr {
+			t.Fatalf("%s: expected %v, got %v", tc.policy, tc.wantErr, err)
+		}
+		if report.Rows[1].Action != tc.want || report.Added+report.Overwritten+report.Skipped+report.Conflicts != 3 {
+			t.Errorf("%s: unexpected report %+v", tc.policy, report)
+		}
+		if fav, _ := s.GetFavorite(ctx, "u1", "b"); fav.Description != tc.wantB {
+			t.Errorf("%s: expected b to be %q, got %q", tc.policy, tc.wantB, fav.Description)
+		}
+		favs, _ := s.MemoryStore.ListFavorites(ctx, "u1")
+		if written := len(favs); tc.policy == PolicyFail && (written != 2 || report.Rows[2].Action != ActionSkip) {
+			t.Errorf("%s: expected only a and b, got %d favorites and %+v", tc.policy, written, report.Rows[2])
+		}
+	}
+}
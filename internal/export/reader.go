@@ -0,0 +1,160 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reader decodes rows written by Writer, one at a time.
+type Reader struct {
+	format Format
+
+	csv    *csv.Reader
+	header map[string]int // CSV column name -> index
+
+	dec     *json.Decoder // JSON array
+	started bool
+
+	lines *bufio.Scanner // NDJSON
+	line  int
+}
+
+// NewReader returns a Reader decoding rows in format f from r.
+func NewReader(r io.Reader, f Format) *Reader {
+	rd := &Reader{format: f}
+	switch f {
+	case CSV:
+		rd.csv = csv.NewReader(r)
+		rd.csv.FieldsPerRecord = -1
+	case JSON:
+		rd.dec = json.NewDecoder(r)
+	default:
+		rd.lines = bufio.NewScanner(r)
+		rd.lines.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	}
+	return rd
+}
+
+// Next returns the next row, or io.EOF when there are none left. Only
+// assetId is required; the asset columns are informational and ignored
+// on import.
+func (r *Reader) Next() (Row, error) {
+	switch r.format {
+	case CSV:
+		return r.nextCSV()
+	case JSON:
+		return r.nextJSON()
+	}
+	return r.nextNDJSON()
+}
+
+func (r *Reader) nextCSV() (Row, error) {
+	if r.header == nil {
+		names, err := r.csv.Read()
+		if err == io.EOF {
+			return Row{}, io.EOF
+		}
+		if err != nil {
+			return Row{}, err
+		}
+		r.header = make(map[string]int, len(names))
+		for i, name := range names {
+			r.header[strings.TrimSpace(name)] = i
+		}
+		if _, ok := r.header["assetId"]; !ok {
+			return Row{}, errors.New("csv header must include assetId")
+		}
+	}
+
+	record, err := r.csv.Read()
+	if err != nil {
+		return Row{}, err
+	}
+	get := func(name string) string {
+		if i, ok := r.header[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	text := func(name string) string { return unescapeCell(get(name)) }
+
+	row := Row{
+		AssetID:     text("assetId"),
+		Type:        text("type"),
+		Name:        text("name"),
+		Description: text("description"),
+		ChartType:   text("chartType"),
+		Metric:      text("metric"),
+		Value:       text("value"),
+		Segment:     text("segment"),
+	}
+	line, _ := r.csv.FieldPos(0)
+	if v := get("createdAt"); v != "" {
+		if row.CreatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			return Row{}, fmt.Errorf("line %d: invalid createdAt %q", line, v)
+		}
+	}
+	if v := get("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return Row{}, fmt.Errorf("line %d: invalid size %q", line, v)
+		}
+		row.Size = &size
+	}
+	return row, nil
+}
+
+func (r *Reader) nextJSON() (Row, error) {
+	if !r.started {
+		tok, err := r.dec.Token()
+		if err == io.EOF {
+			return Row{}, io.EOF
+		}
+		if err != nil {
+			return Row{}, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return Row{}, errors.New("json export must be an array")
+		}
+		r.started = true
+	}
+	if !r.dec.More() {
+		if _, err := r.dec.Token(); err != nil { // Closing bracket
+			return Row{}, err
+		}
+		return Row{}, io.EOF
+	}
+	var row Row
+	if err := r.dec.Decode(&row); err != nil {
+		return Row{}, err
+	}
+	return row, nil
+}
+
+func (r *Reader) nextNDJSON() (Row, error) {
+	for r.lines.Scan() {
+		r.line++
+		line := bytes.TrimSpace(r.lines.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var row Row
+		if err := json.Unmarshal(line, &row); err != nil {
+			return Row{}, fmt.Errorf("line %d: %v", r.line, err)
+		}
+		return row, nil
+	}
+	if err := r.lines.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
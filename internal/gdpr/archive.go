@@ -0,0 +1,139 @@
+// Package gdpr produces signed archives of a user's data for subject access
+// requests and keeps a tamper-evident ledger of erasure receipts.
+package gdpr
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Names of the archive's control files.
+const (
+	ManifestName  = "manifest.json"
+	SignatureName = "manifest.sig"
+)
+
+var (
+	// ErrBadSignature is returned by VerifyArchive when the manifest was
+	// not signed with the given key.
+	ErrBadSignature = errors.New("archive signature does not match")
+
+	// ErrTampered is returned by VerifyArchive when a file is missing or
+	// does not match its manifest digest.
+	ErrTampered = errors.New("archive contents do not match manifest")
+)
+
+// File is one JSON document in an archive.
+type File struct {
+	Name string // e.g. "favorites.json"
+	Data any
+}
+
+// Manifest lists the files in an archive with their digests.
+type Manifest struct {
+	UserID      string       `json:"userId"`
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Files       []FileDigest `json:"files"`
+}
+
+// FileDigest is the size and SHA-256 of one archived file.
+type FileDigest struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Sign returns the signature of data under key, in the same
+// "sha256=<hex HMAC>" form as webhook signatures.
+func Sign(key, data []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WriteArchive writes a zip archive of files to w, followed by a manifest
+// of their digests and the manifest's signature under key.
+func WriteArchive(w io.Writer, userID string, files []File, key []byte, now time.Time) error {
+	zw := zip.NewWriter(w)
+	manifest := Manifest{UserID: userID, GeneratedAt: now.UTC(), Files: make([]FileDigest, 0, len(files))}
+
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.Data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+		if err := writeZipFile(zw, f.Name, data, now); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, FileDigest{Name: f.Name, Size: len(data), SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, ManifestName, data, now); err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, SignatureName, []byte(Sign(key, data)+"\n"), now); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte, now time.Time) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	_, err = fw.Write(data)
+	return err
+}
+
+// VerifyArchive checks the manifest signature of an archive written by
+// WriteArchive and the digest of every file it lists.
+func VerifyArchive(r io.ReaderAt, size int64, key []byte) (Manifest, error) {
+	var manifest Manifest
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return manifest, err
+	}
+	contents := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return manifest, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return manifest, err
+		}
+		contents[f.Name] = data
+	}
+
+	data, sig := contents[ManifestName], bytes.TrimSpace(contents[SignatureName])
+	if !hmac.Equal([]byte(Sign(key, data)), sig) {
+		return manifest, ErrBadSignature
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, err
+	}
+	for _, fd := range manifest.Files {
+		data, ok := contents[fd.Name]
+		sum := sha256.Sum256(data)
+		if !ok || hex.EncodeToString(sum[:]) != fd.SHA256 {
+			return manifest, fmt.Errorf("%w: %s", ErrTampered, fd.Name)
+		}
+	}
+	return manifest, nil
+}
@@ -0,0 +1,85 @@
+package gdpr
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testKey = []byte("test-key")
+
+func TestArchive_SignAndVerify(t *testing.T) {
+	var buf bytes.Buffer
+	files := []File{{Name: "favorites.json", Data: []string{"a", "b"}}}
+	if err := WriteArchive(&buf, "u1", files, testKey, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := VerifyArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), testKey)
+	if err != nil || m.UserID != "u1" || len(m.Files) != 1 {
+		t.Fatalf("verify: %+v, %v", m, err)
+	}
+	if _, err := VerifyArchive(bytes.NewReader(buf.Bytes()), int64(buf.Len()), []byte("other")); err != ErrBadSignature {
+		t.Errorf("expected ErrBadSignature, got %v", err)
+	}
+
+	// Rebuild the archive with a modified data file but the original manifest.
+	zr, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	var tampered bytes.Buffer
+	zw := zip.NewWriter(&tampered)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		var data bytes.Buffer
+		data.ReadFrom(rc)
+		rc.Close()
+		fw, _ := zw.Create(f.Name)
+		if f.Name == "favorites.json" {
+			fw.Write([]byte(`["a"]`))
+		} else {
+			fw.Write(data.Bytes())
+		}
+	}
+	zw.Close()
+	if _, err := VerifyArchive(bytes.NewReader(tampered.Bytes()), int64(tampered.Len()), testKey); !errors.Is(err, ErrTampered) {
+		t.Errorf("expected ErrTampered, got %v", err)
+	}
+}
+
+func TestLedger_ChainPersistsAndDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "receipts.jsonl")
+	l, err := NewLedger(testKey, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, _ := l.Record("alice", map[string]int{"favorites": 2}, time.Now())
+	r2, _ := l.Record("bob", map[string]int{"favorites": 0}, time.Now())
+	if r2.PrevHash != r1.Hash || r1.Subject == r2.Subject || r1.Subject != l.Subject("alice") {
+		t.Fatalf("unexpected receipts %+v %+v", r1, r2)
+	}
+	if strings.Contains(r1.Subject, "alice") {
+		t.Error("receipts must not contain the user ID")
+	}
+
+	l, err = NewLedger(testKey, path)
+	if err != nil || len(l.Receipts()) != 2 {
+		t.Fatalf("reload: %v, %d receipts", err, len(l.Receipts()))
+	}
+
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, bytes.Replace(data, []byte(`"favorites":2`), []byte(`"favorites":1`), 1), 0o600)
+	if _, err := NewLedger(testKey, path); !errors.Is(err, ErrLedgerTampered) {
+		t.Errorf("expected ErrLedgerTampered, got %v", err)
+	}
+
+	// Dropping the first receipt breaks the chain too.
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	os.WriteFile(path, lines[1], 0o600)
+	if _, err := NewLedger(testKey, path); !errors.Is(err, ErrLedgerTampered) {
+		t.Errorf("expected ErrLedgerTampered, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package gdpr
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrLedgerTampered is returned when the receipt chain does not verify.
+var ErrLedgerTampered = errors.New("erasure ledger has been tampered with")
+
+// Receipt records one erasure. It holds no personal data: the user is
+// identified by Subject, a keyed hash of their ID, so the receipt can later
+// prove that a given user was erased without revealing who was.
+//
+// Receipts form a hash chain: each one's Hash covers its content and the
+// previous receipt's Hash, and Signature is an HMAC of Hash, so editing,
+// removing or reordering receipts is detected by Verify.
+type Receipt struct {
+	Seq       uint64         `json:"seq"`
+	Subject   string         `json:"subject"`
+	ErasedAt  time.Time      `json:"erasedAt"`
+	Erased    map[string]int `json:"erased"` // Items erased per kind
+	PrevHash  string         `json:"prevHash"`
+	Hash      string         `json:"hash"`
+	Signature string         `json:"signature"`
+}
+
+// Ledger is an append-only chain of erasure receipts, optionally persisted
+// to a file with one JSON receipt per line.
+type Ledger struct {
+	key  []byte
+	path string
+
+	mu       sync.Mutex
+	receipts []Receipt
+}
+
+// NewLedger returns a ledger signing receipts with key. If path is not
+// empty, existing receipts are loaded from it and verified, and new ones
+// are appended to it.
+func NewLedger(key []byte, path string) (*Ledger, error) {
+	l := &Ledger{key: key, path: path}
+	if path == "" {
+		return l, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r Receipt
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrLedgerTampered, err)
+		}
+		l.receipts = append(l.receipts, r)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := l.Verify(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Subject returns the keyed hash identifying userID in receipts.
+func (l *Ledger) Subject(userID string) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hash returns the chain hash of r, which covers every field except Hash
+// and Signature.
+func hash(r Receipt) string {
+	r.Hash, r.Signature = "", ""
+	data, _ := json.Marshal(r)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Record appends a receipt for erasing userID's data.
+func (l *Ledger) Record(userID string, erased map[string]int, now time.Time) (Receipt, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := Receipt{
+		Seq:      uint64(len(l.receipts)) + 1,
+		Subject:  l.Subject(userID),
+		ErasedAt: now.UTC(),
+		Erased:   erased,
+	}
+	if n := len(l.receipts); n > 0 {
+		r.PrevHash = l.receipts[n-1].Hash
+	}
+	r.Hash = hash(r)
+	r.Signature = Sign(l.key, []byte(r.Hash))
+
+	if l.path != "" {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return r, err
+		}
+		f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return r, err
+		}
+		_, err = f.Write(append(line, '\n'))
+		if err == nil {
+			err = f.Sync()
+		}
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return r, err
+		}
+	}
+	l.receipts = append(l.receipts, r)
+	return r, nil
+}
+
+// Receipts returns every receipt, oldest first.
+func (l *Ledger) Receipts() []Receipt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(make([]Receipt, 0, len(l.receipts)), l.receipts...)
+}
+
+// Verify checks the hash chain and signature of every receipt.
+func (l *Ledger) Verify() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	prev := ""
+	for i, r := range l.receipts {
+		if r.Seq != uint64(i)+1 || r.PrevHash != prev || hash(r) != r.Hash ||
+			!hmac.Equal([]byte(Sign(l.key, []byte(r.Hash))), []byte(r.Signature)) {
+			return fmt.Errorf("%w: receipt %d", ErrLedgerTampered, i+1)
+		}
+		prev = r.Hash
+	}
+	return nil
+}
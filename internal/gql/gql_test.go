@@ -0,0 +1,174 @@
+package gql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+func seedCatalog() {
+	catalog.Initialize()
+	catalog.Global.AddAsset("c1", models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Revenue"}, ChartType: "bar"})
+	catalog.Global.AddAsset("i1", &models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "Engagement"}, Metric: "Engagement", Value: "40%"})
+	catalog.Global.AddAsset("a1", models.Audience{AssetBase: models.AssetBase{ID: "a1", Name: "Gen Z"}, Segment: "18-24", Size: 12000})
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func exec(t *testing.T, h http.Handler, query string, vars map[string]any) response {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": vars})
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, httptest.NewRequest("POST", "/graphql", bytes.NewReader(body)))
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var r response
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		t.Fatalf("bad response: %v", err)
+	}
+	return r
+}
+
+const favoritesQuery = `query($user: ID!, $after: String) {
+	user(id: $user) {
+		favorites(first: 2, after: $after) {
+			totalCount
+			edges {
+				node {
+					description
+					asset {
+						__typename
+						name
+						... on Chart { chartType }
+						... on Insight { metric value }
+						... on Audience { size }
+					}
+				}
+			}
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+type connection struct {
+	User struct {
+		Favorites struct {
+			TotalCount int
+			Edges      []struct {
+				Node struct {
+					Description string
+					Asset       map[string]any
+				}
+			}
+			PageInfo struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+	}
+}
+
+func TestFavoritesConnection(t *testing.T) {
+	seedCatalog()
+	s := store.NewMemoryStore()
+	ctx := context.Background()
+	s.AddFavorite(ctx, "u1", "c1", "mine")
+	s.AddFavorite(ctx, "u1", "i1", "")
+	s.AddFavorite(ctx, "u1", "a1", "")
+	h := NewHandler(s)
+
+	r := exec(t, h, favoritesQuery, map[string]any{"user": "u1"})
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", r.Errors)
+	}
+	var page connection
+	json.Unmarshal(mustJSON(r.Data), &page)
+	fav := page.User.Favorites
+	if fav.TotalCount != 3 || len(fav.Edges) != 2 || !fav.PageInfo.HasNextPage {
+		t.Fatalf("unexpected first page %+v", fav)
+	}
+	if a := fav.Edges[0].Node.Asset; a["__typename"] != "Chart" || a["chartType"] != "bar" || fav.Edges[0].Node.Description != "mine" {
+		t.Errorf("unexpected chart %+v", fav.Edges[0].Node)
+	}
+	if a := fav.Edges[1].Node.Asset; a["__typename"] != "Insight" || a["value"] != "40%" {
+		t.Errorf("pointer assets should resolve to their type, got %v", a)
+	}
+
+	r = exec(t, h, favoritesQuery, map[string]any{"user": "u1", "after": fav.PageInfo.EndCursor})
+	json.Unmarshal(mustJSON(r.Data), &page)
+	fav = page.User.Favorites
+	if len(fav.Edges) != 1 || fav.PageInfo.HasNextPage || fav.Edges[0].Node.Asset["size"] != 12000.0 {
+		t.Errorf("unexpected second page %+v", fav)
+	}
+
+	r = exec(t, h, favoritesQuery, map[string]any{"user": "u1", "after": "bm9wZQ"})
+	if len(r.Errors) != 1 || r.Errors[0].Message != errInvalidCursor.Error() {
+		t.Errorf("expected invalid cursor error, got %+v", r.Errors)
+	}
+}
+
+func TestMutations(t *testing.T) {
+	seedCatalog()
+	s := store.NewMemoryStore()
+	h := NewHandler(s)
+
+	r := exec(t, h, `mutation { addFavorite(userId: "u1", assetId: "c1", description: "x") { assetId description asset { name } } }`, nil)
+	if len(r.Errors) > 0 || !bytes.Contains(r.Data["addFavorite"], []byte(`"Revenue"`)) {
+		t.Fatalf("add: unexpected response %+v %s", r.Errors, r.Data["addFavorite"])
+	}
+	r = exec(t, h, `mutation { addFavorite(userId: "u1", assetId: "c1") { assetId } }`, nil)
+	if len(r.Errors) != 1 || r.Errors[0].Message != store.ErrAlreadyFavorited.Error() {
+		t.Errorf("duplicate add: expected error, got %+v", r.Errors)
+	}
+	r = exec(t, h, `mutation { addFavorite(userId: "u1", assetId: "nope") { assetId } }`, nil)
+	if len(r.Errors) != 1 || r.Errors[0].Message != errAssetNotInCatalog.Error() {
+		t.Errorf("unknown asset: expected error, got %+v", r.Errors)
+	}
+
+	r = exec(t, h, `mutation { editFavorite(userId: "u1", assetId: "c1", description: "y") { description } }`, nil)
+	if string(r.Data["editFavorite"]) != `{"description":"y"}` {
+		t.Errorf("edit: unexpected response %+v %s", r.Errors, r.Data["editFavorite"])
+	}
+
+	r = exec(t, h, `mutation { a: removeFavorite(userId: "u1", assetId: "c1") b: removeFavorite(userId: "u1", assetId: "c1") }`, nil)
+	if string(r.Data["a"]) != "true" || string(r.Data["b"]) != "false" {
+		t.Errorf("remove: expected true then false, got %s %s", r.Data["a"], r.Data["b"])
+	}
+}
+
+func TestAssets(t *testing.T) {
+	seedCatalog()
+	h := NewHandler(store.NewMemoryStore())
+
+	r := exec(t, h, `{ assets(ids: ["a1", "missing", "c1"]) { id } charts: assets(type: CHART) { id } asset(id: "missing") { id } }`, nil)
+	if len(r.Errors) > 0 {
+		t.Fatalf("unexpected errors %+v", r.Errors)
+	}
+	if got := string(r.Data["assets"]); got != `[{"id":"a1"},{"id":"c1"}]` {
+		t.Errorf("expected requested assets in order without unknown IDs, got %s", got)
+	}
+	if got := string(r.Data["charts"]); got != `[{"id":"c1"}]` {
+		t.Errorf("expected only charts, got %s", got)
+	}
+	if got := string(r.Data["asset"]); got != "null" {
+		t.Errorf("expected null for unknown asset, got %s", got)
+	}
+}
+
+func mustJSON(v any) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
@@ -0,0 +1,55 @@
+package gql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"my-solution/internal/store"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxRequestBytes bounds the size of a GraphQL request body.
+const maxRequestBytes = 1 << 20
+
+// maxDepth bounds query nesting. The deepest useful query
+// (user.favorites.edges.node.asset.name) is six levels.
+const maxDepth = 10
+
+// Handler serves GraphQL requests over HTTP.
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// NewHandler returns a handler resolving queries and mutations against s
+// and the global catalog.
+func NewHandler(s store.Store) *Handler {
+	schema := graphql.MustParseSchema(Schema, &resolver{store: s}, graphql.MaxDepth(maxDepth))
+	return &Handler{schema: schema}
+}
+
+// request is a GraphQL-over-HTTP POST body.
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP executes a POSTed query. As usual for GraphQL, errors from
+// resolvers are reported in the response body with status 200; only
+// requests that cannot be executed at all get an error status.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if req.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	res := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
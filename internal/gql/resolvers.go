@@ -0,0 +1,326 @@
+package gql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// maxPageSize caps User.favorites(first:).
+const maxPageSize = 100
+
+var (
+	errAssetNotInCatalog = errors.New("asset not found in catalog")
+	errInvalidCursor     = errors.New("invalid cursor")
+	errInvalidPageSize   = errors.New("first must be between 0 and 100")
+)
+
+// resolver is the root Query and Mutation resolver.
+//
+// Catalog data reaches the client without per-node lookups: a favorites
+// page is sliced from one ListFavorites call, which joins every asset in a
+// single pass, and Query.assets resolves all its IDs with one
+// catalog.GetMany.
+type resolver struct {
+	store store.Store
+}
+
+func (r *resolver) Asset(args struct{ ID graphql.ID }) *assetResolver {
+	a, ok := catalog.Global.Get(string(args.ID))
+	if !ok {
+		return nil
+	}
+	return newAssetResolver(a)
+}
+
+func (r *resolver) Assets(args struct {
+	IDs  *[]graphql.ID
+	Type *string
+}) []*assetResolver {
+	var assets []models.Asset
+	if args.IDs == nil {
+		assets = catalog.Global.List()
+	} else {
+		ids := make([]string, len(*args.IDs))
+		for i, id := range *args.IDs {
+			ids[i] = string(id)
+		}
+		found := catalog.Global.GetMany(ids)
+		for _, id := range ids {
+			if a, ok := found[id]; ok {
+				assets = append(assets, a)
+			}
+		}
+	}
+
+	result := make([]*assetResolver, 0, len(assets))
+	for _, a := range assets {
+		if args.Type != nil && models.AssetType(a) != strings.ToLower(*args.Type) {
+			continue
+		}
+		result = append(result, newAssetResolver(a))
+	}
+	return result
+}
+
+func (r *resolver) User(args struct{ ID graphql.ID }) *userResolver {
+	return &userResolver{id: string(args.ID), store: r.store}
+}
+
+// withActor attributes writes to the user being changed unless an admin
+// request named another actor with X-Actor, as the REST routes do.
+func withActor(ctx context.Context, userID string) context.Context {
+	if history.ActorFrom(ctx) != "" {
+		return ctx
+	}
+	return history.WithActor(ctx, userID)
+}
+
+func (r *resolver) AddFavorite(ctx context.Context, args struct {
+	UserID      graphql.ID
+	AssetID     graphql.ID
+	Description string
+}) (*favoriteResolver, error) {
+	userID := string(args.UserID)
+	assetID, ok := catalog.Global.Resolve(string(args.AssetID))
+	if !ok {
+		return nil, errAssetNotInCatalog
+	}
+	if err := r.store.AddFavorite(withActor(ctx, userID), userID, assetID, args.Description); err != nil {
+		return nil, err
+	}
+	return r.favorite(ctx, userID, assetID)
+}
+
+func (r *resolver) EditFavorite(ctx context.Context, args struct {
+	UserID      graphql.ID
+	AssetID     graphql.ID
+	Description string
+}) (*favoriteResolver, error) {
+	userID, assetID := string(args.UserID), string(args.AssetID)
+	if err := r.store.EditFavoriteDescription(withActor(ctx, userID), userID, assetID, args.Description); err != nil {
+		return nil, err
+	}
+	return r.favorite(ctx, userID, assetID)
+}
+
+func (r *resolver) RemoveFavorite(ctx context.Context, args struct {
+	UserID  graphql.ID
+	AssetID graphql.ID
+}) (bool, error) {
+	userID := string(args.UserID)
+	result, err := r.store.RemoveFavorite(withActor(ctx, userID), userID, string(args.AssetID))
+	if err != nil {
+		return false, err
+	}
+	return result == store.Removed, nil
+}
+
+func (r *resolver) favorite(ctx context.Context, userID, assetID string) (*favoriteResolver, error) {
+	fav, err := r.store.GetFavorite(ctx, userID, assetID)
+	if err != nil {
+		return nil, err
+	}
+	return &favoriteResolver{fav: fav}, nil
+}
+
+type userResolver struct {
+	id    string
+	store store.Store
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return graphql.ID(u.id)
+}
+
+func (u *userResolver) Favorites(ctx context.Context, args struct {
+	First int32
+	After *string
+}) (*connectionResolver, error) {
+	if args.First < 0 || args.First > maxPageSize {
+		return nil, errInvalidPageSize
+	}
+	first := int(args.First)
+
+	favorites, err := u.store.ListFavorites(ctx, u.id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if args.After != nil {
+		after, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		start = -1
+		for i, fav := range favorites {
+			if fav.AssetID == after {
+				start = i + 1
+				break
+			}
+		}
+		if start == -1 {
+			// The favorite the cursor points at has since been removed.
+			return nil, errInvalidCursor
+		}
+	}
+	end := min(start+first, len(favorites))
+	return &connectionResolver{total: len(favorites), page: favorites[start:end], hasNext: end < len(favorites)}, nil
+}
+
+func encodeCursor(assetID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(assetID))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) == 0 {
+		return "", errInvalidCursor
+	}
+	return string(b), nil
+}
+
+type connectionResolver struct {
+	total   int
+	page    []models.FavoriteWithAsset
+	hasNext bool
+}
+
+func (c *connectionResolver) TotalCount() int32 {
+	return int32(c.total)
+}
+
+func (c *connectionResolver) Edges() []*edgeResolver {
+	edges := make([]*edgeResolver, len(c.page))
+	for i, fav := range c.page {
+		edges[i] = &edgeResolver{fav: fav}
+	}
+	return edges
+}
+
+func (c *connectionResolver) PageInfo() *pageInfoResolver {
+	p := &pageInfoResolver{hasNext: c.hasNext}
+	if n := len(c.page); n > 0 {
+		cursor := encodeCursor(c.page[n-1].AssetID)
+		p.endCursor = &cursor
+	}
+	return p
+}
+
+type edgeResolver struct {
+	fav models.FavoriteWithAsset
+}
+
+func (e *edgeResolver) Cursor() string {
+	return encodeCursor(e.fav.AssetID)
+}
+
+func (e *edgeResolver) Node() *favoriteResolver {
+	return &favoriteResolver{fav: e.fav}
+}
+
+type pageInfoResolver struct {
+	hasNext   bool
+	endCursor *string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.hasNext
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	return p.endCursor
+}
+
+type favoriteResolver struct {
+	fav models.FavoriteWithAsset
+}
+
+func (f *favoriteResolver) AssetID() graphql.ID {
+	return graphql.ID(f.fav.AssetID)
+}
+
+func (f *favoriteResolver) Asset() *assetResolver {
+	if f.fav.Asset == nil {
+		return nil
+	}
+	return newAssetResolver(f.fav.Asset)
+}
+
+func (f *favoriteResolver) Description() string {
+	return f.fav.Description
+}
+
+func (f *favoriteResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: f.fav.CreatedAt}
+}
+
+// assetResolver resolves the Asset interface. Catalog entries may be
+// stored as values or pointers; both resolve to the same concrete type.
+type assetResolver struct {
+	asset models.Asset
+}
+
+func newAssetResolver(a models.Asset) *assetResolver {
+	switch v := a.(type) {
+	case *models.Chart:
+		a = *v
+	case *models.Insight:
+		a = *v
+	case *models.Audience:
+		a = *v
+	}
+	return &assetResolver{asset: a}
+}
+
+func (a *assetResolver) ID() graphql.ID      { return graphql.ID(a.asset.GetID()) }
+func (a *assetResolver) Name() string        { return a.asset.GetName() }
+func (a *assetResolver) Description() string { return a.asset.GetDescription() }
+
+func (a *assetResolver) ToChart() (*chartResolver, bool) {
+	c, ok := a.asset.(models.Chart)
+	return &chartResolver{c}, ok
+}
+
+func (a *assetResolver) ToInsight() (*insightResolver, bool) {
+	i, ok := a.asset.(models.Insight)
+	return &insightResolver{i}, ok
+}
+
+func (a *assetResolver) ToAudience() (*audienceResolver, bool) {
+	au, ok := a.asset.(models.Audience)
+	return &audienceResolver{au}, ok
+}
+
+type chartResolver struct{ c models.Chart }
+
+func (r *chartResolver) ID() graphql.ID      { return graphql.ID(r.c.ID) }
+func (r *chartResolver) Name() string        { return r.c.Name }
+func (r *chartResolver) Description() string { return r.c.Description }
+func (r *chartResolver) ChartType() string   { return r.c.ChartType }
+func (r *chartResolver) DataSource() string  { return r.c.DataSource }
+
+type insightResolver struct{ i models.Insight }
+
+func (r *insightResolver) ID() graphql.ID      { return graphql.ID(r.i.ID) }
+func (r *insightResolver) Name() string        { return r.i.Name }
+func (r *insightResolver) Description() string { return r.i.Description }
+func (r *insightResolver) Metric() string      { return r.i.Metric }
+func (r *insightResolver) Value() string       { return r.i.Value }
+
+type audienceResolver struct{ a models.Audience }
+
+func (r *audienceResolver) ID() graphql.ID      { return graphql.ID(r.a.ID) }
+func (r *audienceResolver) Name() string        { return r.a.Name }
+func (r *audienceResolver) Description() string { return r.a.Description }
+func (r *audienceResolver) Segment() string     { return r.a.Segment }
+func (r *audienceResolver) Size() int32         { return int32(r.a.Size) }
@@ -0,0 +1,95 @@
+// Package gql serves the favorites API over GraphQL, so that clients can
+// fetch a user's favorites with only the fields they need and the related
+// catalog data in one round trip.
+package gql
+
+// Schema is the GraphQL schema served at /graphql.
+const Schema = `
+schema {
+	query: Query
+	mutation: Mutation
+}
+
+scalar Time
+
+type Query {
+	# An asset from the catalog, following aliases of replaced assets.
+	asset(id: ID!): Asset
+	# Assets by ID, or the whole catalog if ids is omitted. Unknown IDs are skipped.
+	assets(ids: [ID!], type: AssetType): [Asset!]!
+	user(id: ID!): User!
+}
+
+type Mutation {
+	addFavorite(userId: ID!, assetId: ID!, description: String = ""): Favorite!
+	editFavorite(userId: ID!, assetId: ID!, description: String!): Favorite!
+	# Returns whether the asset was favorited.
+	removeFavorite(userId: ID!, assetId: ID!): Boolean!
+}
+
+enum AssetType {
+	CHART
+	INSIGHT
+	AUDIENCE
+}
+
+interface Asset {
+	id: ID!
+	name: String!
+	description: String!
+}
+
+type Chart implements Asset {
+	id: ID!
+	name: String!
+	description: String!
+	chartType: String!
+	dataSource: String!
+}
+
+type Insight implements Asset {
+	id: ID!
+	name: String!
+	description: String!
+	metric: String!
+	value: String!
+}
+
+type Audience implements Asset {
+	id: ID!
+	name: String!
+	description: String!
+	segment: String!
+	size: Int!
+}
+
+type User {
+	id: ID!
+	# The user's favorites in list order. first is capped at 100.
+	favorites(first: Int = 20, after: String): FavoriteConnection!
+}
+
+type FavoriteConnection {
+	totalCount: Int!
+	edges: [FavoriteEdge!]!
+	pageInfo: PageInfo!
+}
+
+type FavoriteEdge {
+	cursor: String!
+	node: Favorite!
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	endCursor: String
+}
+
+type Favorite {
+	assetId: ID!
+	# Null if the asset has left the catalog.
+	asset: Asset
+	description: String!
+	createdAt: Time!
+}
+`
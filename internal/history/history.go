@@ -0,0 +1,403 @@
+// Package history keeps a per-user audit trail of changes to favorites and
+// lets users undo their most recent change.
+package history
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+var (
+	// ErrNothingToUndo is returned by Undo when the user has no change
+	// left that can be reverted.
+	ErrNothingToUndo = errors.New("nothing to undo")
+
+	// ErrUndoConflict is returned by Undo when the favorite has changed
+	// in a way that prevents reverting, e.g. it was removed before an edit
+	// to it is undone.
+	ErrUndoConflict = errors.New("favorite changed since, cannot undo")
+)
+
+// DefaultMaxEntries is the number of history entries kept per user when
+// Store.MaxEntries is zero.
+const DefaultMaxEntries = 1000
+
+// Action is the kind of change an Entry records.
+type Action string
+
+const (
+	Added    Action = "added"
+	Removed  Action = "removed"
+	Edited   Action = "edited"
+	Restored Action = "restored" // Brought back from the trash
+	Migrated Action = "migrated" // Moved from a retired asset ID to its replacement
+)
+
+// Entry is one change to a user's favorites.
+type Entry struct {
+	Seq            uint64    `json:"seq"` // Per-user sequence number, starting at 1
+	UserID         string    `json:"userId"`
+	AssetID        string    `json:"assetId"`
+	FromAssetID    string    `json:"fromAssetId,omitempty"` // Retired asset ID, for Migrated
+	Action         Action    `json:"action"`
+	Actor          string    `json:"actor,omitempty"` // Who made the change, see WithActor
+	OldDescription string    `json:"oldDescription,omitempty"`
+	NewDescription string    `json:"newDescription,omitempty"`
+	Time           time.Time `json:"time"`
+	Undone         bool      `json:"undone,omitempty"` // Reverted by a later entry
+	Undoes         uint64    `json:"undoes,omitempty"` // Seq of the entry this one reverted
+}
+
+// Page is a slice of a user's history, most recent first.
+type Page struct {
+	Entries []Entry `json:"entries"`
+	// NextBefore is the cursor for the next (older) page, 0 if there is none.
+	NextBefore uint64 `json:"nextBefore,omitempty"`
+}
+
+type actorKey struct{}
+
+// WithActor records actor as the author of writes made with ctx.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor recorded with WithActor, or "" if none was.
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// Store decorates a store.Store so that every successful write is recorded
+// in the user's history.
+type Store struct {
+	store.Store
+
+	// MaxEntries bounds the history kept per user; the oldest entries are
+	// dropped first. Zero means DefaultMaxEntries.
+	MaxEntries int
+
+	// mu serialises writes so that the description read before a write is
+	// still current when it is applied.
+	mu      sync.Mutex
+	entries map[string][]Entry
+	lastSeq map[string]uint64
+}
+
+// NewStore wraps s so that writes are recorded.
+func NewStore(s store.Store) *Store {
+	return &Store{
+		Store:   s,
+		entries: make(map[string][]Entry),
+		lastSeq: make(map[string]uint64),
+	}
+}
+
+// Unwrap returns the decorated store.
+func (s *Store) Unwrap() store.Store {
+	return s.Store
+}
+
+// AddFavorite adds the favorite and records it.
+func (s *Store) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.Store.AddFavorite(ctx, userID, assetID, description); err != nil {
+		return err
+	}
+	s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Added, NewDescription: description})
+	return nil
+}
+
+// ListFavorites is passed through unchanged.
+func (s *Store) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.Store.ListFavorites(ctx, userID)
+}
+
+// UpsertFavorite adds or updates the favorite and records it as added or
+// edited accordingly.
+func (s *Store) UpsertFavorite(ctx context.Context, userID, assetID, description string) (store.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fav, _, err := s.lookup(ctx, userID, assetID)
+	if err != nil {
+		return 0, err
+	}
+	result, err := s.Store.UpsertFavorite(ctx, userID, assetID, description)
+	if err != nil {
+		return 0, err
+	}
+	if result == store.Updated {
+		s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Edited, OldDescription: fav.Description, NewDescription: description})
+	} else {
+		s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Added, NewDescription: description})
+	}
+	return result, nil
+}
+
+// RemoveFavorite removes the favorite and records it. Removing an asset
+// that is not favorited changes nothing and is not recorded.
+func (s *Store) RemoveFavorite(ctx context.Context, userID, assetID string) (store.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fav, found, err := s.lookup(ctx, userID, assetID)
+	if err != nil {
+		return 0, err
+	}
+	result, err := s.Store.RemoveFavorite(ctx, userID, assetID)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Removed, OldDescription: fav.Description})
+	}
+	return result, nil
+}
+
+// EditFavoriteDescription updates the description and records the old and
+// new values.
+func (s *Store) EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fav, _, err := s.lookup(ctx, userID, assetID)
+	if err != nil {
+		return err
+	}
+	if err := s.Store.EditFavoriteDescription(ctx, userID, assetID, desc); err != nil {
+		return err
+	}
+	s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Edited, OldDescription: fav.Description, NewDescription: desc})
+	return nil
+}
+
+// Forwards reports that Trash, UserEraser and AssetMigrator are only
+// available when the decorated store provides them.
+func (s *Store) Forwards(capability any) bool {
+	switch capability.(type) {
+	case *store.Trash, *store.UserEraser, *store.AssetMigrator:
+		return true
+	}
+	return false
+}
+
+// ListTrash is forwarded to the underlying store.Trash.
+func (s *Store) ListTrash(ctx context.Context, userID string) ([]models.TrashedFavorite, error) {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+	return t.ListTrash(ctx, userID)
+}
+
+// RestoreFavorite restores the favorite from the trash and records it.
+func (s *Store) RestoreFavorite(ctx context.Context, userID, assetID string) error {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := t.RestoreFavorite(ctx, userID, assetID); err != nil {
+		return err
+	}
+	fav, _, _ := s.lookup(ctx, userID, assetID)
+	s.record(ctx, Entry{UserID: userID, AssetID: assetID, Action: Restored, NewDescription: fav.Description})
+	return nil
+}
+
+// PurgeTrash is forwarded to the underlying store.Trash.
+func (s *Store) PurgeTrash(ctx context.Context, now time.Time) (int, error) {
+	t, ok := store.Find[store.Trash](s.Store)
+	if !ok {
+		return 0, store.ErrUnsupported
+	}
+	return t.PurgeTrash(ctx, now)
+}
+
+// MigrateAsset migrates favorites in the underlying store and records a
+// Migrated entry for each affected user.
+func (s *Store) MigrateAsset(ctx context.Context, oldID, newID string) ([]store.MigratedFavorite, error) {
+	migrator, ok := store.Find[store.AssetMigrator](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	moved, err := migrator.MigrateAsset(ctx, oldID, newID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range moved {
+		s.record(ctx, Entry{UserID: m.UserID, AssetID: newID, FromAssetID: oldID, Action: Migrated, NewDescription: m.Description})
+	}
+	return moved, nil
+}
+
+// History returns up to limit of the user's entries with Seq below before,
+// most recent first. A before of 0 starts at the latest entry and a limit
+// of 0 returns all of them.
+func (s *Store) History(userID string, before uint64, limit int) Page {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.entries[userID]
+	page := Page{Entries: make([]Entry, 0)}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if before != 0 && e.Seq >= before {
+			continue
+		}
+		if limit > 0 && len(page.Entries) == limit {
+			page.NextBefore = page.Entries[limit-1].Seq
+			break
+		}
+		page.Entries = append(page.Entries, e)
+	}
+	return page
+}
+
+// Undo reverts the user's most recent change that has not been undone
+// yet and returns the entry recording the revert. Undoing is itself
+// recorded but cannot be undone, so repeated calls walk back through the
+// history like an undo stack. Migrations cannot be undone either, since
+// the asset ID they moved away from is retired, and are skipped.
+func (s *Store) Undo(ctx context.Context, userID string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.entries[userID]
+	idx := -1
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].Undone && entries[i].Undoes == 0 && entries[i].Action != Migrated {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return Entry{}, ErrNothingToUndo
+	}
+	target := entries[idx]
+
+	revert, err := s.revert(ctx, target)
+	if err != nil {
+		return Entry{}, err
+	}
+	entries[idx].Undone = true
+	revert.Undoes = target.Seq
+	return s.record(ctx, revert), nil
+}
+
+// revert applies the inverse of e to the underlying store and returns the
+// entry describing it. Caller must hold s.mu.
+func (s *Store) revert(ctx context.Context, e Entry) (Entry, error) {
+	inverse := Entry{UserID: e.UserID, AssetID: e.AssetID}
+	switch e.Action {
+	case Added, Restored:
+		if _, found, err := s.lookup(ctx, e.UserID, e.AssetID); err != nil {
+			return Entry{}, err
+		} else if !found {
+			return Entry{}, ErrUndoConflict
+		}
+		inverse.Action = Removed
+		inverse.OldDescription = e.NewDescription
+		_, err := s.Store.RemoveFavorite(ctx, e.UserID, e.AssetID)
+		return inverse, err
+
+	case Removed:
+		// Prefer the trash so that position and CreatedAt survive.
+		inverse.NewDescription = e.OldDescription
+		if t, ok := store.Find[store.Trash](s.Store); ok {
+			err := t.RestoreFavorite(ctx, e.UserID, e.AssetID)
+			if err == nil {
+				inverse.Action = Restored
+				return inverse, nil
+			}
+			if !errors.Is(err, store.ErrAssetNotFound) {
+				return Entry{}, undoError(err)
+			}
+		}
+		inverse.Action = Added
+		return inverse, undoError(s.Store.AddFavorite(ctx, e.UserID, e.AssetID, e.OldDescription))
+
+	case Edited:
+		inverse.Action = Edited
+		inverse.OldDescription = e.NewDescription
+		inverse.NewDescription = e.OldDescription
+		return inverse, undoError(s.Store.EditFavoriteDescription(ctx, e.UserID, e.AssetID, e.OldDescription))
+	}
+	return Entry{}, ErrNothingToUndo
+}
+
+// undoError maps store errors caused by later changes to ErrUndoConflict.
+func undoError(err error) error {
+	if errors.Is(err, store.ErrAlreadyFavorited) || errors.Is(err, store.ErrAssetNotFound) {
+		return ErrUndoConflict
+	}
+	return err
+}
+
+// record appends e to the user's history. Caller must hold s.mu.
+func (s *Store) record(ctx context.Context, e Entry) Entry {
+	s.lastSeq[e.UserID]++
+	e.Seq = s.lastSeq[e.UserID]
+	e.Actor = ActorFrom(ctx)
+	e.Time = time.Now()
+
+	limit := s.MaxEntries
+	if limit <= 0 {
+		limit = DefaultMaxEntries
+	}
+	entries := append(s.entries[e.UserID], e)
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	s.entries[e.UserID] = entries
+	return e
+}
+
+// lookup returns the user's current favorite for assetID, including ones
+// whose asset has left the catalog.
+func (s *Store) lookup(ctx context.Context, userID, assetID string) (models.FavoriteWithAsset, bool, error) {
+	fav, err := s.Store.GetFavorite(ctx, userID, assetID)
+	if errors.Is(err, store.ErrAssetNotFound) {
+		return models.FavoriteWithAsset{}, false, nil
+	}
+	if err != nil {
+		return models.FavoriteWithAsset{}, false, err
+	}
+	return fav, true, nil
+}
+
+// EraseUser erases the user's data from the underlying store and then
+// their history.
+func (s *Store) EraseUser(ctx context.Context, userID string) (map[string]int, error) {
+	eraser, ok := store.Find[store.UserEraser](s.Store)
+	if !ok {
+		return nil, store.ErrUnsupported
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	erased, err := eraser.EraseUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	erased["history"] = len(s.entries[userID])
+	delete(s.entries, userID)
+	delete(s.lastSeq, userID)
+	return erased, nil
+}
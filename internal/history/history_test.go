@@ -0,0 +1,127 @@
+package history
+
+import (
+	"context"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+	"my-solution/internal/store/storetest"
+)
+
+func TestStoreConformance(t *testing.T) {
+	storetest.Run(t, func() store.Store { return NewStore(store.NewMemoryStore()) })
+}
+
+func TestStore_RecordsChanges(t *testing.T) {
+	catalog.Initialize()
+	ctx := WithActor(context.Background(), "support-bot")
+	s := NewStore(store.NewMemoryStore())
+
+	s.AddFavorite(ctx, "u1", "a", "first")
+	s.EditFavoriteDescription(ctx, "u1", "a", "second")
+	s.RemoveFavorite(ctx, "u1", "a")
+	s.RemoveFavorite(ctx, "u1", "never-added")
+
+	page := s.History("u1", 0, 0)
+	if len(page.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", page.Entries)
+	}
+	removed, edited, added := page.Entries[0], page.Entries[1], page.Entries[2]
+	if added.Action != Added || added.NewDescription != "first" || added.Seq != 1 {
+		t.Errorf("unexpected add entry %+v", added)
+	}
+	if edited.Action != Edited || edited.OldDescription != "first" || edited.NewDescription != "second" {
+		t.Errorf("unexpected edit entry %+v", edited)
+	}
+	if removed.Action != Removed || removed.OldDescription != "second" || removed.Actor != "support-bot" {
+		t.Errorf("unexpected remove entry %+v", removed)
+	}
+}
+
+func TestStore_RecordsUpserts(t *testing.T) {
+	catalog.Initialize()
+	ctx := context.Background()
+	s := NewStore(store.NewMemoryStore())
+
+	s.UpsertFavorite(ctx, "u1", "a", "first")
+	s.UpsertFavorite(ctx, "u1", "a", "second")
+
+	page := s.History("u1", 0, 0)
+	if len(page.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", page.Entries)
+	}
+	if e := page.Entries[1]; e.Action != Added || e.NewDescription != "first" {
+		t.Errorf("unexpected create entry %+v", e)
+	}
+	if e := page.Entries[0]; e.Action != Edited || e.OldDescription != "first" || e.NewDescription != "second" {
+		t.Errorf("unexpected update entry %+v", e)
+	}
+}
+
+func TestStore_HistoryPaging(t *testing.T) {
+	catalog.Initialize()
+	ctx := context.Background()
+	s := NewStore(store.NewMemoryStore())
+	s.MaxEntries = 4
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		s.AddFavorite(ctx, "u1", id, "")
+	}
+
+	page := s.History("u1", 0, 3)
+	if len(page.Entries) != 3 || page.Entries[0].AssetID != "e" || page.NextBefore != 3 {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	page = s.History("u1", page.NextBefore, 3)
+	if len(page.Entries) != 1 || page.Entries[0].AssetID != "b" || page.NextBefore != 0 {
+		t.Fatalf("oldest entry should have been dropped, got %+v", page)
+	}
+}
+
+func TestStore_Undo(t *testing.T) {
+	catalog.Initialize()
+	ctx := context.Background()
+	for _, id := range []string{"a", "b"} {
+		catalog.Global.AddAsset(id, models.Chart{AssetBase: models.AssetBase{ID: id, Name: id}, ChartType: "bar"})
+	}
+	mem := store.NewMemoryStore()
+	s := NewStore(mem)
+
+	s.AddFavorite(ctx, "u1", "a", "note a")
+	s.AddFavorite(ctx, "u1", "b", "note b")
+	s.EditFavoriteDescription(ctx, "u1", "a", "edited")
+	s.RemoveFavorite(ctx, "u1", "a")
+
+	// Undo the removal: restored from the trash at its old position.
+	e, err := s.Undo(ctx, "u1")
+	if err != nil || e.Action != Restored || e.Undoes != 4 {
+		t.Fatalf("undo remove: %+v, %v", e, err)
+	}
+	favs, _ := mem.ListFavorites(ctx, "u1")
+	if len(favs) != 2 || favs[0].AssetID != "a" || favs[0].Description != "edited" {
+		t.Fatalf("unexpected favorites %+v", favs)
+	}
+
+	// Undo the edit, then the add of b; the undo entries are skipped.
+	if _, err := s.Undo(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Undo(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	favs, _ = mem.ListFavorites(ctx, "u1")
+	if len(favs) != 1 || favs[0].Description != "note a" {
+		t.Fatalf("unexpected favorites %+v", favs)
+	}
+
+	// a was removed behind the decorator's back, so its add cannot be undone.
+	mem.RemoveFavorite(ctx, "u1", "a")
+	if _, err := s.Undo(ctx, "u1"); err != ErrUndoConflict {
+		t.Errorf("expected ErrUndoConflict, got %v", err)
+	}
+
+	if _, err := s.Undo(ctx, "nobody"); err != ErrNothingToUndo {
+		t.Errorf("expected ErrNothingToUndo, got %v", err)
+	}
+}
@@ -101,3 +101,28 @@ func (i Insight) GetDescription() string { return i.Description }
 func (a Audience) GetID() string          { return a.ID }
 func (a Audience) GetName() string        { return a.Name }
 func (a Audience) GetDescription() string { return a.Description }
+
+// Asset type names used by the API for filtering and grouping.
+const (
+	TypeChart    = "chart"
+	TypeInsight  = "insight"
+	TypeAudience = "audience"
+)
+
+// AssetType returns the type name of a, or "" for unknown asset types.
+func AssetType(a Asset) string {
+	switch a.(type) {
+	case Chart, *Chart:
+		return TypeChart
+	case Insight, *Insight:
+		return TypeInsight
+	case Audience, *Audience:
+		return TypeAudience
+	}
+	return ""
+}
+
+// ValidAssetType reports whether t is a known asset type name.
+func ValidAssetType(t string) bool {
+	return t == TypeChart || t == TypeInsight || t == TypeAudience
+}
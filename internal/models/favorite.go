@@ -16,5 +16,20 @@ type FavoriteWithAsset struct {
 	AssetID     string    `json:"assetId"`
 	Description string    `json:"description"`
 	CreatedAt   time.Time `json:"createdAt"`
-	Asset       Asset     `json:"asset"` // Full asset from catalog
+	Asset       Asset     `json:"asset"`            // Full asset from catalog
+	Status      string    `json:"status,omitempty"` // StatusUnavailable when the asset left the catalog
+}
+
+// StatusUnavailable marks a favorite whose asset is no longer in the catalog.
+const StatusUnavailable = "unavailable"
+
+// TrashedFavorite is a removed favorite that can still be restored.
+type TrashedFavorite struct {
+	AssetID     string    `json:"assetId"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	DeletedAt   time.Time `json:"deletedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"` // When the purge job deletes it
+	Asset       Asset     `json:"asset"`
+	Status      string    `json:"status,omitempty"`
 }
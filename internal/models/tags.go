@@ -0,0 +1,26 @@
+package models
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// hashtag matches #tags in favorite descriptions.
+var hashtag = regexp.MustCompile(`#([\pL\pN_-]+)`)
+
+// Tags returns the lower-cased #hashtags in a favorite description,
+// deduplicated and sorted.
+func Tags(description string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, m := range hashtag.FindAllStringSubmatch(description, -1) {
+		tag := strings.ToLower(m[1])
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,282 @@
+// Package orphans detects and cleans up favorites whose asset has left
+// the catalog.
+//
+// An orphan is first seen by a sweep and left alone for a grace period, in
+// case the asset is republished. Once the grace period has passed the
+// favorites are archived (removed from the users' lists but kept here for
+// inspection), and archived entries are purged after a retention period.
+package orphans
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/store"
+)
+
+// ErrUnsupported is returned when the store cannot enumerate favorites
+// by asset.
+var ErrUnsupported = errors.New("store does not support orphan detection")
+
+// Actor is the history actor recorded for favorites the sweeper archives.
+const Actor = "orphan-sweeper"
+
+// Report describes the favorites referencing one missing asset.
+type Report struct {
+	AssetID       string    `json:"assetId"`
+	FavoriteCount int       `json:"favoriteCount"`
+	Users         []string  `json:"users"`
+	FirstSeen     time.Time `json:"firstSeen,omitempty"` // Zero until a sweep has seen it
+	ArchiveAfter  time.Time `json:"archiveAfter,omitempty"`
+}
+
+// ArchivedFavorite is a favorite removed because its asset disappeared.
+type ArchivedFavorite struct {
+	UserID      string    `json:"userId"`
+	AssetID     string    `json:"assetId"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ArchivedAt  time.Time `json:"archivedAt"`
+	PurgeAfter  time.Time `json:"purgeAfter"`
+}
+
+// SweepResult summarises one sweep.
+type SweepResult struct {
+	Detected int `json:"detected"` // Newly seen orphaned assets
+	Archived int `json:"archived"` // Favorites moved to the archive
+	Purged   int `json:"purged"`   // Archive entries deleted
+}
+
+// Sweeper tracks orphaned favorites and archives and purges them.
+type Sweeper struct {
+	// Store is used for removals, so that the decorators it is made of
+	// observe them; pass the outermost store (e.g. history.Store) for
+	// archived favorites to show up in users' history. It must expose
+	// store.PopularityIndex through Find.
+	Store     store.Store
+	Grace     time.Duration // How long an orphan is kept before archiving
+	Retention time.Duration // How long archived favorites are kept
+	Now       func() time.Time
+
+	mu        sync.Mutex
+	firstSeen map[string]time.Time // assetID -> when a sweep first saw it missing
+	archive   []ArchivedFavorite
+}
+
+// NewSweeper creates a sweeper for s.
+func NewSweeper(s store.Store, grace, retention time.Duration) *Sweeper {
+	return &Sweeper{
+		Store:     s,
+		Grace:     grace,
+		Retention: retention,
+		Now:       time.Now,
+		firstSeen: make(map[string]time.Time),
+	}
+}
+
+// orphanedAssets returns favorited asset IDs that are not in the catalog,
+// with the users who favorited them.
+func (s *Sweeper) orphanedAssets(ctx context.Context) (map[string][]string, error) {
+	idx, ok := store.Find[store.PopularityIndex](s.Store)
+	if !ok {
+		return nil, ErrUnsupported
+	}
+	counts, err := idx.FavoriteCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string)
+	for assetID := range counts {
+		if _, ok := catalog.Global.Get(assetID); ok {
+			continue
+		}
+		users, err := idx.Favoriters(ctx, assetID)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(users)
+		result[assetID] = users
+	}
+	return result, nil
+}
+
+// Report returns the current orphans, most favorited first.
+func (s *Sweeper) Report(ctx context.Context) ([]Report, error) {
+	orphaned, err := s.orphanedAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Report, 0, len(orphaned))
+	for assetID, users := range orphaned {
+		r := Report{AssetID: assetID, FavoriteCount: len(users), Users: users}
+		if seen, ok := s.firstSeen[assetID]; ok {
+			r.FirstSeen = seen
+			r.ArchiveAfter = seen.Add(s.Grace)
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FavoriteCount != result[j].FavoriteCount {
+			return result[i].FavoriteCount > result[j].FavoriteCount
+		}
+		return result[i].AssetID < result[j].AssetID
+	})
+	return result, nil
+}
+
+// Sweep records newly orphaned assets, archives orphans past their grace
+// period and purges archive entries past retention.
+func (s *Sweeper) Sweep(ctx context.Context) (SweepResult, error) {
+	var res SweepResult
+	orphaned, err := s.orphanedAssets(ctx)
+	if err != nil {
+		return res, err
+	}
+	now := s.Now()
+
+	s.mu.Lock()
+	// Forget assets that have come back or no longer have favorites.
+	for assetID := range s.firstSeen {
+		if _, ok := orphaned[assetID]; !ok {
+			delete(s.firstSeen, assetID)
+		}
+	}
+	var due []string
+	for assetID := range orphaned {
+		seen, ok := s.firstSeen[assetID]
+		if !ok {
+			s.firstSeen[assetID] = now
+			res.Detected++
+			continue
+		}
+		if now.Sub(seen) >= s.Grace {
+			due = append(due, assetID)
+		}
+	}
+	s.mu.Unlock()
+
+	lister, ok := store.Find[store.UnavailableLister](s.Store)
+	if !ok {
+		return res, ErrUnsupported
+	}
+	for _, assetID := range due {
+		for _, userID := range orphaned[assetID] {
+			archived, err := s.archive1(ctx, lister, userID, assetID, now)
+			if err != nil {
+				return res, err
+			}
+			if archived {
+				res.Archived++
+			}
+		}
+		s.mu.Lock()
+		delete(s.firstSeen, assetID)
+		s.mu.Unlock()
+	}
+
+	res.Purged = s.purge(now)
+	return res, nil
+}
+
+// archive1 moves one user's orphaned favorite into the archive, unless its
+// asset has been republished since the sweep looked for orphans.
+func (s *Sweeper) archive1(ctx context.Context, lister store.UnavailableLister, userID, assetID string, now time.Time) (bool, error) {
+	if _, ok := catalog.Global.Get(assetID); ok {
+		return false, nil
+	}
+	favs, err := lister.ListFavoritesWithUnavailable(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, fav := range favs {
+		if fav.AssetID != assetID {
+			continue
+		}
+		if _, err := s.Store.RemoveFavorite(history.WithActor(ctx, Actor), userID, assetID); err != nil {
+			return false, err
+		}
+		s.mu.Lock()
+		s.archive = append(s.archive, ArchivedFavorite{
+			UserID:      userID,
+			AssetID:     assetID,
+			Description: fav.Description,
+			CreatedAt:   fav.CreatedAt,
+			ArchivedAt:  now,
+			PurgeAfter:  now.Add(s.Retention),
+		})
+		s.mu.Unlock()
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *Sweeper) purge(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.archive[:0]
+	for _, a := range s.archive {
+		if now.Before(a.PurgeAfter) {
+			kept = append(kept, a)
+		}
+	}
+	purged := len(s.archive) - len(kept)
+	s.archive = kept
+	return purged
+}
+
+// Archived returns the archived favorites, oldest first.
+func (s *Sweeper) Archived() []ArchivedFavorite {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ArchivedFavorite(nil), s.archive...)
+}
+
+// EraseUser drops the user's archived favorites and returns how many
+// there were.
+func (s *Sweeper) EraseUser(userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.archive[:0]
+	for _, a := range s.archive {
+		if a.UserID != userID {
+			kept = append(kept, a)
+		}
+	}
+	erased := len(s.archive) - len(kept)
+	s.archive = kept
+	return erased
+}
+
+// Run sweeps every interval until ctx is done.
+func (s *Sweeper) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := s.Sweep(ctx)
+			if err != nil {
+				log.Printf("orphan sweep failed: %v", err)
+				continue
+			}
+			if res != (SweepResult{}) {
+				log.Printf("orphan sweep: detected=%d archived=%d purged=%d", res.Detected, res.Archived, res.Purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package orphans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+func TestSweeper_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	catalog.Initialize()
+	catalog.Global.AddAsset("live", models.Chart{AssetBase: models.AssetBase{ID: "live", Name: "Live"}, ChartType: "bar"})
+
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "live", "")
+	s.AddFavorite(ctx, "u1", "gone", "my note")
+	s.AddFavorite(ctx, "u2", "gone", "")
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sw := NewSweeper(s, time.Hour, 24*time.Hour)
+	sw.Now = func() time.Time { return now }
+
+	report, err := sw.Report(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report) != 1 || report[0].AssetID != "gone" || report[0].FavoriteCount != 2 || !report[0].FirstSeen.IsZero() {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	// First sweep only records the orphan.
+	res, _ := sw.Sweep(ctx)
+	if res != (SweepResult{Detected: 1}) {
+		t.Errorf("first sweep: %+v", res)
+	}
+	report, _ = sw.Report(ctx)
+	if !report[0].FirstSeen.Equal(now) || !report[0].ArchiveAfter.Equal(now.Add(time.Hour)) {
+		t.Errorf("grace period not reported: %+v", report[0])
+	}
+
+	// Within the grace period nothing is archived.
+	now = now.Add(30 * time.Minute)
+	if res, _ := sw.Sweep(ctx); res != (SweepResult{}) {
+		t.Errorf("sweep within grace: %+v", res)
+	}
+
+	// After the grace period both favorites are archived.
+	now = now.Add(time.Hour)
+	if res, _ := sw.Sweep(ctx); res.Archived != 2 {
+		t.Errorf("sweep after grace: %+v", res)
+	}
+	favs, _ := s.ListFavoritesWithUnavailable(ctx, "u1")
+	if len(favs) != 1 || favs[0].AssetID != "live" {
+		t.Errorf("orphan not removed from user: %+v", favs)
+	}
+	archived := sw.Archived()
+	if len(archived) != 2 || archived[0].Description != "my note" {
+		t.Errorf("unexpected archive %+v", archived)
+	}
+	if report, _ := sw.Report(ctx); len(report) != 0 {
+		t.Errorf("expected empty report after archiving, got %+v", report)
+	}
+
+	// After retention the archive is purged.
+	now = now.Add(25 * time.Hour)
+	if res, _ := sw.Sweep(ctx); res.Purged != 2 {
+		t.Errorf("sweep after retention: %+v", res)
+	}
+	if n := len(sw.Archived()); n != 0 {
+		t.Errorf("expected empty archive, got %d", n)
+	}
+}
+
+func TestSweeper_AssetReturnsDuringGrace(t *testing.T) {
+	ctx := context.Background()
+	catalog.Initialize()
+
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "flaky", "")
+
+	now := time.Now()
+	sw := NewSweeper(s, time.Hour, time.Hour)
+	sw.Now = func() time.Time { return now }
+	sw.Sweep(ctx)
+
+	catalog.Global.AddAsset("flaky", models.Chart{AssetBase: models.AssetBase{ID: "flaky", Name: "F"}, ChartType: "bar"})
+	now = now.Add(2 * time.Hour)
+	if res, _ := sw.Sweep(ctx); res.Archived != 0 {
+		t.Errorf("republished asset was archived: %+v", res)
+	}
+	if favs, _ := s.ListFavorites(ctx, "u1"); len(favs) != 1 {
+		t.Errorf("favorite lost: %+v", favs)
+	}
+}
+
+func TestSweeper_ArchiveIsRecordedInHistory(t *testing.T) {
+	ctx := context.Background()
+	catalog.Initialize()
+
+	hs := history.NewStore(store.NewMemoryStore())
+	hs.AddFavorite(ctx, "u1", "gone", "")
+
+	now := time.Now()
+	sw := NewSweeper(hs, time.Hour, time.Hour)
+	sw.Now = func() time.Time { return now }
+	sw.Sweep(ctx)
+	now = now.Add(2 * time.Hour)
+	if res, _ := sw.Sweep(ctx); res.Archived != 1 {
+		t.Fatalf("expected the orphan to be archived, got %+v", res)
+	}
+
+	page := hs.History("u1", 0, 10)
+	if len(page.Entries) != 2 || page.Entries[0].Action != history.Removed || page.Entries[0].Actor != Actor {
+		t.Errorf("expected a removal by %s, got %+v", Actor, page.Entries)
+	}
+}
+
+func TestSweeper_ArchiveRechecksCatalog(t *testing.T) {
+	ctx := context.Background()
+	catalog.Initialize()
+
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "recreated", "")
+
+	// The asset is re-created after the sweep found it orphaned but
+	// before its favorites are archived.
+	catalog.Global.AddAsset("recreated", models.Chart{AssetBase: models.AssetBase{ID: "recreated", Name: "R"}, ChartType: "bar"})
+	sw := NewSweeper(s, time.Hour, time.Hour)
+	archived, err := sw.archive1(ctx, s, "u1", "recreated", time.Now())
+	if err != nil || archived {
+		t.Errorf("live favorite was archived: %v, %v", archived, err)
+	}
+	if favs, _ := s.ListFavorites(ctx, "u1"); len(favs) != 1 {
+		t.Errorf("favorite lost: %+v", favs)
+	}
+}
@@ -0,0 +1,198 @@
+// Package recommend implements item-to-item "users who favorited this also
+// favorited" recommendations.
+//
+// The engine keeps a co-occurrence matrix of assets favorited by the same
+// user and updates it incrementally as favorites are added and removed, so
+// queries never rescan all users.
+package recommend
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"my-solution/internal/events"
+	"my-solution/internal/store"
+)
+
+// Metric selects how co-occurrence counts are turned into similarity.
+type Metric string
+
+const (
+	// Jaccard is |A∩B| / |A∪B| over the sets of users who favorited each asset.
+	Jaccard Metric = "jaccard"
+	// Cosine is |A∩B| / sqrt(|A|·|B|).
+	Cosine Metric = "cosine"
+)
+
+// Scored is a candidate asset with its aggregated similarity score.
+type Scored struct {
+	AssetID string
+	Score   float64
+}
+
+// Engine maintains the co-occurrence matrix. It is safe for concurrent use.
+type Engine struct {
+	mu        sync.RWMutex
+	userItems map[string]map[string]struct{} // userID -> favorited assetIDs
+	itemCount map[string]int                 // assetID -> number of users
+	cooc      map[string]map[string]int      // assetID -> assetID -> users with both
+}
+
+// NewEngine creates an empty engine.
+func NewEngine() *Engine {
+	return &Engine{
+		userItems: make(map[string]map[string]struct{}),
+		itemCount: make(map[string]int),
+		cooc:      make(map[string]map[string]int),
+	}
+}
+
+// Add records that userID favorited assetID. Repeated adds are ignored.
+func (e *Engine) Add(userID, assetID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	items := e.userItems[userID]
+	if items == nil {
+		items = make(map[string]struct{})
+		e.userItems[userID] = items
+	}
+	if _, ok := items[assetID]; ok {
+		return
+	}
+
+	for other := range items {
+		e.bump(assetID, other, 1)
+		e.bump(other, assetID, 1)
+	}
+	items[assetID] = struct{}{}
+	e.itemCount[assetID]++
+}
+
+// Remove records that userID no longer favorites assetID. Removing an
+// asset the user had not favorited is a no-op.
+func (e *Engine) Remove(userID, assetID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	items := e.userItems[userID]
+	if _, ok := items[assetID]; !ok {
+		return
+	}
+	delete(items, assetID)
+	if len(items) == 0 {
+		delete(e.userItems, userID)
+	}
+
+	for other := range items {
+		e.bump(assetID, other, -1)
+		e.bump(other, assetID, -1)
+	}
+	if e.itemCount[assetID]--; e.itemCount[assetID] == 0 {
+		delete(e.itemCount, assetID)
+	}
+}
+
+func (e *Engine) bump(a, b string, delta int) {
+	row := e.cooc[a]
+	if row == nil {
+		row = make(map[string]int)
+		e.cooc[a] = row
+	}
+	row[b] += delta
+	if row[b] == 0 {
+		delete(row, b)
+		if len(row) == 0 {
+			delete(e.cooc, a)
+		}
+	}
+}
+
+// Observe applies a favorite event. It is intended to be registered with
+// events.Bus.AddListener so the engine follows every store write.
+func (e *Engine) Observe(ev events.Event) {
+	switch ev.Type {
+	case events.FavoriteAdded:
+		e.Add(ev.UserID, ev.AssetID)
+	case events.FavoriteRemoved:
+		e.Remove(ev.UserID, ev.AssetID)
+	}
+}
+
+// Load seeds the engine from a store's popularity index, for stores that
+// already hold favorites when the engine is created.
+func (e *Engine) Load(ctx context.Context, idx store.PopularityIndex) error {
+	counts, err := idx.FavoriteCounts(ctx)
+	if err != nil {
+		return err
+	}
+	for assetID := range counts {
+		users, err := idx.Favoriters(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		for _, userID := range users {
+			e.Add(userID, assetID)
+		}
+	}
+	return nil
+}
+
+// Similarity returns the similarity of two assets under m.
+func (e *Engine) Similarity(a, b string, m Metric) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.similarity(a, b, m)
+}
+
+func (e *Engine) similarity(a, b string, m Metric) float64 {
+	both := float64(e.cooc[a][b])
+	if both == 0 {
+		return 0
+	}
+	na, nb := float64(e.itemCount[a]), float64(e.itemCount[b])
+	if m == Cosine {
+		return both / math.Sqrt(na*nb)
+	}
+	return both / (na + nb - both)
+}
+
+// Recommend returns assets userID has not favorited, ranked by the sum of
+// their similarity to each asset the user has favorited. keep, if non-nil,
+// filters candidates (e.g. by asset type or catalog presence) before the
+// limit is applied. A non-positive limit returns every candidate.
+func (e *Engine) Recommend(userID string, m Metric, limit int, keep func(assetID string) bool) []Scored {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	items := e.userItems[userID]
+	scores := make(map[string]float64)
+	for item := range items {
+		for candidate := range e.cooc[item] {
+			if _, owned := items[candidate]; owned {
+				continue
+			}
+			scores[candidate] += e.similarity(item, candidate, m)
+		}
+	}
+
+	result := make([]Scored, 0, len(scores))
+	for assetID, score := range scores {
+		if keep != nil && !keep(assetID) {
+			continue
+		}
+		result = append(result, Scored{AssetID: assetID, Score: score})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].AssetID < result[j].AssetID
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package recommend
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"my-solution/internal/events"
+	"my-solution/internal/store"
+)
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestEngine_Similarity(t *testing.T) {
+	e := NewEngine()
+	// a: u1 u2 u3, b: u1 u2, c: u3
+	for _, u := range []string{"u1", "u2", "u3"} {
+		e.Add(u, "a")
+	}
+	e.Add("u1", "b")
+	e.Add("u2", "b")
+	e.Add("u3", "c")
+
+	if got := e.Similarity("a", "b", Jaccard); !near(got, 2.0/3.0) {
+		t.Errorf("jaccard(a,b) = %v", got)
+	}
+	if got := e.Similarity("a", "b", Cosine); !near(got, 2/math.Sqrt(6)) {
+		t.Errorf("cosine(a,b) = %v", got)
+	}
+	if got := e.Similarity("b", "c", Jaccard); got != 0 {
+		t.Errorf("jaccard(b,c) = %v, want 0", got)
+	}
+}
+
+func TestEngine_Recommend(t *testing.T) {
+	e := NewEngine()
+	e.Add("u1", "a")
+	e.Add("u1", "b")
+	e.Add("u2", "a")
+	e.Add("u2", "b")
+	e.Add("u3", "a")
+	e.Add("u3", "c")
+	e.Add("me", "a")
+
+	got := e.Recommend("me", Jaccard, 0, nil)
+	if len(got) != 2 || got[0].AssetID != "b" || got[1].AssetID != "c" {
+		t.Fatalf("unexpected recommendations %+v", got)
+	}
+	for _, s := range got {
+		if s.AssetID == "a" {
+			t.Error("recommended an asset the user already favorited")
+		}
+	}
+
+	got = e.Recommend("me", Jaccard, 1, nil)
+	if len(got) != 1 {
+		t.Errorf("limit not applied: %+v", got)
+	}
+	got = e.Recommend("me", Jaccard, 0, func(id string) bool { return id != "b" })
+	if len(got) != 1 || got[0].AssetID != "c" {
+		t.Errorf("filter not applied: %+v", got)
+	}
+	if got := e.Recommend("stranger", Jaccard, 0, nil); len(got) != 0 {
+		t.Errorf("expected nothing for user without favorites, got %+v", got)
+	}
+}
+
+func TestEngine_IncrementalRemoveMatchesRebuild(t *testing.T) {
+	inc := NewEngine()
+	inc.Add("u1", "a")
+	inc.Add("u1", "b")
+	inc.Add("u1", "c")
+	inc.Add("u2", "b")
+	inc.Add("u2", "c")
+	inc.Remove("u1", "b")
+	inc.Remove("u1", "zzz") // not favorited; no-op
+	inc.Add("u1", "a")      // duplicate; no-op
+
+	fresh := NewEngine()
+	fresh.Add("u1", "a")
+	fresh.Add("u1", "c")
+	fresh.Add("u2", "b")
+	fresh.Add("u2", "c")
+
+	for _, pair := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}} {
+		if a, b := inc.Similarity(pair[0], pair[1], Jaccard), fresh.Similarity(pair[0], pair[1], Jaccard); !near(a, b) {
+			t.Errorf("%v: incremental %v != rebuilt %v", pair, a, b)
+		}
+	}
+	if len(inc.cooc["b"]) != 1 {
+		t.Errorf("stale co-occurrence entries left behind: %v", inc.cooc["b"])
+	}
+}
+
+func TestEngine_ObserveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "a", "")
+	s.AddFavorite(ctx, "u1", "b", "")
+
+	e := NewEngine()
+	if err := e.Load(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	e.Observe(events.Event{Type: events.FavoriteAdded, UserID: "u2", AssetID: "a"})
+	e.Observe(events.Event{Type: events.DescriptionEdited, UserID: "u2", AssetID: "zzz"})
+
+	got := e.Recommend("u2", Cosine, 0, nil)
+	if len(got) != 1 || got[0].AssetID != "b" {
+		t.Errorf("unexpected recommendations %+v", got)
+	}
+}
@@ -0,0 +1,36 @@
+package store
+
+import "context"
+
+// UserEraser is implemented by stores that can delete everything they hold
+// about a user, for right-to-erasure requests. Decorators that keep their
+// own per-user data implement it too and forward to the store they wrap.
+type UserEraser interface {
+	// EraseUser deletes the user's data and returns how many items of each
+	// kind (e.g. "favorites", "trash") were erased.
+	EraseUser(ctx context.Context, userID string) (map[string]int, error)
+}
+
+// EraseUser deletes the user's favorites and trash.
+func (s *MemoryStore) EraseUser(ctx context.Context, userID string) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	erased := map[string]int{
+		"favorites": len(s.users[userID]),
+		"trash":     len(s.trash[userID]),
+	}
+	for _, fav := range s.users[userID] {
+		delete(s.popularity[fav.AssetID], userID)
+		if len(s.popularity[fav.AssetID]) == 0 {
+			delete(s.popularity, fav.AssetID)
+		}
+	}
+	delete(s.users, userID)
+	delete(s.members, userID)
+	delete(s.trash, userID)
+	return erased, nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"my-solution/internal/models"
+)
+
+// LegacyStore is the context-free form of Store that predates context
+// propagation. It exists only to ease the migration and will be removed
+// once all callers and backends pass a context.
+type LegacyStore interface {
+	AddFavorite(userID, assetID, description string) error
+	ListFavorites(userID string) ([]models.FavoriteWithAsset, error)
+	RemoveFavorite(userID, assetID string) error
+	EditFavoriteDescription(userID, assetID, desc string) error
+}
+
+// Legacy adapts a Store for callers that have not been migrated yet.
+// Every call runs with context.Background(), so it cannot be cancelled.
+func Legacy(s Store) LegacyStore {
+	return legacyAdapter{s: s}
+}
+
+type legacyAdapter struct {
+	s Store
+}
+
+func (a legacyAdapter) AddFavorite(userID, assetID, description string) error {
+	return a.s.AddFavorite(context.Background(), userID, assetID, description)
+}
+
+func (a legacyAdapter) ListFavorites(userID string) ([]models.FavoriteWithAsset, error) {
+	return a.s.ListFavorites(context.Background(), userID)
+}
+
+func (a legacyAdapter) RemoveFavorite(userID, assetID string) error {
+	_, err := a.s.RemoveFavorite(context.Background(), userID, assetID)
+	return err
+}
+
+func (a legacyAdapter) EditFavoriteDescription(userID, assetID, desc string) error {
+	return a.s.EditFavoriteDescription(context.Background(), userID, assetID, desc)
+}
+
+// FromLegacy wraps a backend that still implements the context-free
+// methods so it satisfies Store. The context is checked before each call
+// is forwarded, but an in-flight call cannot be interrupted.
+func FromLegacy(l LegacyStore) Store {
+	return contextAdapter{l: l}
+}
+
+type contextAdapter struct {
+	l LegacyStore
+}
+
+func (a contextAdapter) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.l.AddFavorite(userID, assetID, description)
+}
+
+func (a contextAdapter) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return a.l.ListFavorites(userID)
+}
+
+// GetFavorite scans the legacy backend's list, which has no single lookup.
+// Favorites whose asset has left the catalog are not listed, so they are
+// reported as not found.
+func (a contextAdapter) GetFavorite(ctx context.Context, userID, assetID string) (models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return models.FavoriteWithAsset{}, err
+	}
+	favs, err := a.l.ListFavorites(userID)
+	if err != nil {
+		return models.FavoriteWithAsset{}, err
+	}
+	for _, fav := range favs {
+		if fav.AssetID == assetID {
+			return fav, nil
+		}
+	}
+	return models.FavoriteWithAsset{}, ErrAssetNotFound
+}
+
+// UpsertFavorite tries to add the favorite and edits it if that fails
+// because it exists. Legacy backends have no atomic upsert, so a
+// concurrent removal between the two calls surfaces as ErrAssetNotFound.
+func (a contextAdapter) UpsertFavorite(ctx context.Context, userID, assetID, description string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	err := a.l.AddFavorite(userID, assetID, description)
+	if err == nil {
+		return Created, nil
+	}
+	if !errors.Is(err, ErrAlreadyFavorited) {
+		return 0, err
+	}
+	if err := a.l.EditFavoriteDescription(userID, assetID, description); err != nil {
+		return 0, err
+	}
+	return Updated, nil
+}
+
+// RemoveFavorite looks the favorite up before removing it, since legacy
+// backends do not report whether anything was removed. Favorites whose
+// asset has left the catalog cannot be looked up, so they are removed but
+// reported as NotFound.
+func (a contextAdapter) RemoveFavorite(ctx context.Context, userID, assetID string) (Result, error) {
+	result := Removed
+	if _, err := a.GetFavorite(ctx, userID, assetID); errors.Is(err, ErrAssetNotFound) {
+		result = NotFound
+	} else if err != nil {
+		return 0, err
+	}
+	if err := a.l.RemoveFavorite(userID, assetID); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func (a contextAdapter) EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.l.EditFavoriteDescription(userID, assetID, desc)
+}
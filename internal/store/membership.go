@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+
+	"my-solution/internal/models"
+)
+
+// MembershipIndex is implemented by stores that keep a per-user set of
+// favorited asset IDs, so that a catalog page can be marked up for a user
+// without listing and joining all of their favorites.
+type MembershipIndex interface {
+	// FavoriteDescriptions returns the user's favorited asset IDs mapped
+	// to the user's description of each.
+	FavoriteDescriptions(ctx context.Context, userID string) (map[string]string, error)
+}
+
+// FavoriteDescriptions returns the user's favorited asset IDs mapped to
+// their descriptions.
+func (s *MemoryStore) FavoriteDescriptions(ctx context.Context, userID string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]string, len(s.members[userID]))
+	for assetID, fav := range s.members[userID] {
+		result[assetID] = fav.Description
+	}
+	return result, nil
+}
+
+// setMember records the current state of one of userID's favorites.
+// Caller must hold s.mu.
+func (s *MemoryStore) setMember(userID string, fav models.Favorite) {
+	if s.members[userID] == nil {
+		s.members[userID] = make(map[string]models.Favorite)
+	}
+	s.members[userID][fav.AssetID] = fav
+}
+
+// deleteMember records that userID no longer favorites assetID. Caller must
+// hold s.mu.
+func (s *MemoryStore) deleteMember(userID, assetID string) {
+	delete(s.members[userID], assetID)
+	if len(s.members[userID]) == 0 {
+		delete(s.members, userID)
+	}
+}
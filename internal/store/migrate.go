@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// MigratedFavorite describes one user's favorite rewritten by MigrateAsset.
+type MigratedFavorite struct {
+	UserID      string
+	Description string // Description now held by the new favorite
+	// Merged is true when the user already favorited the new asset, so the
+	// old favorite was folded into it rather than renamed.
+	Merged bool
+}
+
+// AssetMigrator is implemented by stores that can rewrite favorites from
+// one asset ID to another in place.
+type AssetMigrator interface {
+	// MigrateAsset points every favorite of oldID at newID, keeping its
+	// description, CreatedAt and position in the user's list. Stores that
+	// implement Trash migrate trashed favorites too, so that restoring one
+	// does not bring back oldID.
+	MigrateAsset(ctx context.Context, oldID, newID string) ([]MigratedFavorite, error)
+}
+
+// MigrateAsset rewrites favorites of oldID to newID. If a user already
+// favorites newID, the old entry is dropped; the surviving entry keeps
+// the earlier CreatedAt and takes the old description if it had none.
+// Trashed favorites are rewritten the same way, and dropped if newID is
+// already in the user's trash.
+func (s *MemoryStore) MigrateAsset(ctx context.Context, oldID, newID string) ([]MigratedFavorite, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := s.popularity[oldID]
+	result := make([]MigratedFavorite, 0, len(users))
+
+	for userID := range users {
+		favorites := s.users[userID]
+		oldIdx, newIdx := -1, -1
+		for i, fav := range favorites {
+			switch fav.AssetID {
+			case oldID:
+				oldIdx = i
+			case newID:
+				newIdx = i
+			}
+		}
+		if oldIdx == -1 {
+			continue
+		}
+
+		old := favorites[oldIdx]
+		s.deleteMember(userID, oldID)
+		if newIdx == -1 {
+			favorites[oldIdx].AssetID = newID
+			s.setMember(userID, favorites[oldIdx])
+			result = append(result, MigratedFavorite{UserID: userID, Description: old.Description})
+		} else {
+			merged := &favorites[newIdx]
+			if merged.Description == "" {
+				merged.Description = old.Description
+			}
+			if old.CreatedAt.Before(merged.CreatedAt) {
+				merged.CreatedAt = old.CreatedAt
+			}
+			s.setMember(userID, *merged)
+			result = append(result, MigratedFavorite{UserID: userID, Description: merged.Description, Merged: true})
+			s.users[userID] = append(favorites[:oldIdx], favorites[oldIdx+1:]...)
+		}
+
+		if s.popularity[newID] == nil {
+			s.popularity[newID] = make(map[string]time.Time)
+		}
+		if existing, ok := s.popularity[newID][userID]; !ok || old.CreatedAt.Before(existing) {
+			s.popularity[newID][userID] = old.CreatedAt
+		}
+	}
+	delete(s.popularity, oldID)
+	s.migrateTrash(oldID, newID)
+
+	return result, nil
+}
+
+// migrateTrash rewrites trashed favorites of oldID to newID. Caller must
+// hold s.mu.
+func (s *MemoryStore) migrateTrash(oldID, newID string) {
+	for userID, entries := range s.trash {
+		oldIdx, hasNew := -1, false
+		for i, e := range entries {
+			switch e.fav.AssetID {
+			case oldID:
+				oldIdx = i
+			case newID:
+				hasNew = true
+			}
+		}
+		switch {
+		case oldIdx == -1:
+		case hasNew:
+			s.trash[userID] = append(entries[:oldIdx], entries[oldIdx+1:]...)
+		default:
+			entries[oldIdx].fav.AssetID = newID
+		}
+	}
+}
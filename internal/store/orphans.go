@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"my-solution/internal/models"
+)
+
+// UnavailableLister is implemented by stores that can return favorites
+// whose asset has left the catalog instead of silently skipping them.
+type UnavailableLister interface {
+	// ListFavoritesWithUnavailable is like ListFavorites, but favorites
+	// whose asset is missing from the catalog are included with a nil
+	// Asset and Status set to models.StatusUnavailable.
+	ListFavoritesWithUnavailable(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error)
+}
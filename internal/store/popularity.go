@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// PopularityIndex is implemented by stores that maintain a reverse index
+// from asset ID to the users who favorited it.
+type PopularityIndex interface {
+	// FavoriteCounts returns the number of users who favorited each asset.
+	// Assets nobody has favorited are absent.
+	FavoriteCounts(ctx context.Context) (map[string]int, error)
+
+	// Favoriters returns the IDs of users who favorited assetID.
+	Favoriters(ctx context.Context, assetID string) ([]string, error)
+
+	// FavoriteTimes returns, per asset, the CreatedAt of every favorite
+	// created at or after since.
+	FavoriteTimes(ctx context.Context, since time.Time) (map[string][]time.Time, error)
+}
+
+// FavoriteCounts returns the number of users who favorited each asset.
+func (s *MemoryStore) FavoriteCounts(ctx context.Context) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int, len(s.popularity))
+	for assetID, users := range s.popularity {
+		counts[assetID] = len(users)
+	}
+	return counts, nil
+}
+
+// Favoriters returns the IDs of users who favorited assetID.
+func (s *MemoryStore) Favoriters(ctx context.Context, assetID string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := make([]string, 0, len(s.popularity[assetID]))
+	for userID := range s.popularity[assetID] {
+		users = append(users, userID)
+	}
+	return users, nil
+}
+
+// FavoriteTimes returns, per asset, the CreatedAt of favorites created at
+// or after since.
+func (s *MemoryStore) FavoriteTimes(ctx context.Context, since time.Time) (map[string][]time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string][]time.Time)
+	for assetID, users := range s.popularity {
+		for _, createdAt := range users {
+			if !createdAt.Before(since) {
+				result[assetID] = append(result[assetID], createdAt)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+
+	"my-solution/internal/models"
+)
+
+// ScanBatch is how many favorites a FavoriteScanner reads per lock hold.
+const ScanBatch = 256
+
+// FavoriteScanner is implemented by stores that can hand out a user's
+// favorites a few at a time, so that callers such as the export can
+// write each one out without holding the whole list in memory.
+type FavoriteScanner interface {
+	// ScanFavorites calls fn with each of the user's favorites, in list
+	// order and joined with the catalog like ListFavorites, stopping at
+	// the first error fn returns. Favorites are read in batches and fn is
+	// called without the store locked, so writes made during the scan may
+	// or may not be seen.
+	ScanFavorites(ctx context.Context, userID string, fn func(models.FavoriteWithAsset) error) error
+}
+
+// ResumeScan returns the index in favorites from which a scan that last
+// reported the favorite of lastID, found at index lastIndex, continues.
+// If that favorite has been removed since, the scan continues at the same
+// index, which skips favorites only if earlier ones were removed as well.
+func ResumeScan(favorites []models.Favorite, lastID string, lastIndex int) int {
+	if lastIndex < 0 {
+		return 0
+	}
+	if lastIndex < len(favorites) && favorites[lastIndex].AssetID == lastID {
+		return lastIndex + 1
+	}
+	for i, fav := range favorites {
+		if fav.AssetID == lastID {
+			return i + 1
+		}
+	}
+	return min(lastIndex, len(favorites))
+}
+
+// ScanFavorites calls fn with each of the user's favorites, reading them
+// in batches of ScanBatch.
+func (s *MemoryStore) ScanFavorites(ctx context.Context, userID string, fn func(models.FavoriteWithAsset) error) error {
+	lastID, lastIndex := "", -1
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.mu.Lock()
+		favorites := s.users[userID]
+		start := ResumeScan(favorites, lastID, lastIndex)
+		batch := append([]models.Favorite(nil), favorites[start:min(start+ScanBatch, len(favorites))]...)
+		s.mu.Unlock()
+
+		if len(batch) == 0 {
+			return nil
+		}
+		for _, fav := range batch {
+			if item := joinAsset(fav); item.Status != models.StatusUnavailable {
+				if err := fn(item); err != nil {
+					return err
+				}
+			}
+		}
+		lastID, lastIndex = batch[len(batch)-1].AssetID, start+len(batch)-1
+	}
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -10,25 +11,67 @@ import (
 )
 
 var (
-	ErrAssetNotFound = errors.New("asset not found")
-	ErrDuplicateName = errors.New("asset with this name already exists")
+	ErrAssetNotFound    = errors.New("asset not found")
+	ErrDuplicateName    = errors.New("asset with this name already exists")
+	ErrAlreadyFavorited = errors.New("asset already favorited")
+	ErrUnsupported      = errors.New("operation not supported by store")
 )
 
+// Result says what a write did, for callers that need to tell a change
+// from a no-op.
+type Result int
+
+const (
+	Created  Result = iota + 1 // The favorite did not exist and was added
+	Updated                    // The favorite existed and was changed
+	Removed                    // The favorite existed and was removed
+	NotFound                   // The favorite did not exist and nothing changed
+)
+
+func (r Result) String() string {
+	switch r {
+	case Created:
+		return "created"
+	case Updated:
+		return "updated"
+	case Removed:
+		return "removed"
+	case NotFound:
+		return "not found"
+	}
+	return "unknown"
+}
+
 // Store defines the interface for managing user favorites.
 // Favorites store only references to assets (by ID) plus user metadata,
 // not the full asset objects themselves.
+//
+// Every method takes a context so that slow backends can observe client
+// disconnects and request deadlines. Implementations return ctx.Err() when
+// the context is done before the operation completes.
 type Store interface {
 	// AddFavorite adds an asset to user's favorites by asset ID
-	AddFavorite(userID, assetID, description string) error
+	AddFavorite(ctx context.Context, userID, assetID, description string) error
 
 	// ListFavorites returns user's favorites with full asset data joined from catalog
-	ListFavorites(userID string) ([]models.FavoriteWithAsset, error)
+	ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error)
+
+	// GetFavorite returns one of user's favorites with its asset joined from
+	// catalog, or ErrAssetNotFound if the asset is not favorited. A favorite
+	// whose asset has left the catalog is returned with StatusUnavailable.
+	GetFavorite(ctx context.Context, userID, assetID string) (models.FavoriteWithAsset, error)
+
+	// UpsertFavorite adds an asset to user's favorites, or sets the
+	// description if it is already favorited. It returns Created or Updated.
+	UpsertFavorite(ctx context.Context, userID, assetID, description string) (Result, error)
 
-	// RemoveFavorite removes an asset from user's favorites
-	RemoveFavorite(userID, assetID string) error
+	// RemoveFavorite removes an asset from user's favorites. Removing an
+	// asset that is not favorited is not an error: it returns NotFound
+	// rather than Removed.
+	RemoveFavorite(ctx context.Context, userID, assetID string) (Result, error)
 
 	// EditFavoriteDescription updates the user's custom description for a favorite
-	EditFavoriteDescription(userID, assetID, desc string) error
+	EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error
 }
 
 // MemoryStore manages user favorites in-memory with concurrency safety.
@@ -36,65 +79,162 @@ type Store interface {
 type MemoryStore struct {
 	mu    sync.Mutex
 	users map[string][]models.Favorite // userID -> array of favorite references
+
+	// Membership index: userID -> assetID -> favorite, mirroring users.
+	members map[string]map[string]models.Favorite
+
+	// Reverse index: assetID -> userID -> CreatedAt of that user's favorite.
+	popularity map[string]map[string]time.Time
+
+	// Removed favorites kept for restore, per user, oldest first.
+	trash map[string][]trashEntry
+
+	// TrashRetention is how long removed favorites can be restored before
+	// PurgeTrash deletes them for good.
+	TrashRetention time.Duration
 }
 
+// DefaultTrashRetention is the TrashRetention of a new MemoryStore.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
 // NewMemoryStore initializes and returns a new in-memory store.
 func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
-		users: make(map[string][]models.Favorite),
+		users:          make(map[string][]models.Favorite),
+		members:        make(map[string]map[string]models.Favorite),
+		popularity:     make(map[string]map[string]time.Time),
+		trash:          make(map[string][]trashEntry),
+		TrashRetention: DefaultTrashRetention,
 	}
 }
 
 // AddFavorite adds a favorite reference by asset ID.
-func (s *MemoryStore) AddFavorite(userID, assetID, description string) error {
+func (s *MemoryStore) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Check if already favorited
-	for _, fav := range s.users[userID] {
-		if fav.AssetID == assetID {
-			return errors.New("asset already favorited")
-		}
+	if _, ok := s.members[userID][assetID]; ok {
+		return ErrAlreadyFavorited
+	}
+	s.add(userID, assetID, description)
+	return nil
+}
+
+// UpsertFavorite adds the favorite or updates its description.
+func (s *MemoryStore) UpsertFavorite(ctx context.Context, userID, assetID, description string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.members[userID][assetID]; ok {
+		s.edit(userID, assetID, description)
+		return Updated, nil
 	}
+	s.add(userID, assetID, description)
+	return Created, nil
+}
 
-	// Add new favorite reference
-	s.users[userID] = append(s.users[userID], models.Favorite{
+// add appends a new favorite reference. Caller must hold s.mu and have
+// checked that it is not already favorited.
+func (s *MemoryStore) add(userID, assetID, description string) {
+	now := time.Now()
+	fav := models.Favorite{
 		AssetID:     assetID,
 		Description: description,
-		CreatedAt:   time.Now(),
-	})
-	return nil
+		CreatedAt:   now,
+	}
+	s.users[userID] = append(s.users[userID], fav)
+	s.setMember(userID, fav)
+
+	if s.popularity[assetID] == nil {
+		s.popularity[assetID] = make(map[string]time.Time)
+	}
+	s.popularity[assetID][userID] = now
 }
 
 // ListFavorites returns user's favorites with full asset data from catalog.
-func (s *MemoryStore) ListFavorites(userID string) ([]models.FavoriteWithAsset, error) {
+func (s *MemoryStore) ListFavorites(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.listFavorites(ctx, userID, false)
+}
+
+// ListFavoritesWithUnavailable is like ListFavorites but also returns
+// favorites whose asset is missing from the catalog, marked unavailable.
+func (s *MemoryStore) ListFavoritesWithUnavailable(ctx context.Context, userID string) ([]models.FavoriteWithAsset, error) {
+	return s.listFavorites(ctx, userID, true)
+}
+
+func (s *MemoryStore) listFavorites(ctx context.Context, userID string, includeUnavailable bool) ([]models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	favorites := s.users[userID]
 	result := make([]models.FavoriteWithAsset, 0, len(favorites))
 
-	for _, fav := range favorites {
-		// Look up asset in catalog
-		asset, ok := catalog.Global.Get(fav.AssetID)
-		if !ok {
+	for i, fav := range favorites {
+		// Large lists can take a while to join; bail out if the caller is gone.
+		if i%1024 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		item := joinAsset(fav)
+		if item.Status == models.StatusUnavailable && !includeUnavailable {
 			// Asset no longer exists in catalog, skip it
 			continue
 		}
-
-		result = append(result, models.FavoriteWithAsset{
-			AssetID:     fav.AssetID,
-			Description: fav.Description,
-			CreatedAt:   fav.CreatedAt,
-			Asset:       asset,
-		})
+		result = append(result, item)
 	}
 
 	return result, nil
 }
 
+// GetFavorite looks up a single favorite in the membership index.
+func (s *MemoryStore) GetFavorite(ctx context.Context, userID, assetID string) (models.FavoriteWithAsset, error) {
+	if err := ctx.Err(); err != nil {
+		return models.FavoriteWithAsset{}, err
+	}
+	s.mu.Lock()
+	fav, ok := s.members[userID][assetID]
+	s.mu.Unlock()
+	if !ok {
+		return models.FavoriteWithAsset{}, ErrAssetNotFound
+	}
+	return joinAsset(fav), nil
+}
+
+// joinAsset returns fav with its asset from catalog, marked unavailable if
+// the asset has left the catalog.
+func joinAsset(fav models.Favorite) models.FavoriteWithAsset {
+	item := models.FavoriteWithAsset{
+		AssetID:     fav.AssetID,
+		Description: fav.Description,
+		CreatedAt:   fav.CreatedAt,
+	}
+	asset, ok := catalog.Global.Get(fav.AssetID)
+	if !ok {
+		item.Status = models.StatusUnavailable
+	}
+	item.Asset = asset
+	return item
+}
+
 // RemoveFavorite removes an asset from a user's favorites by asset ID.
-func (s *MemoryStore) RemoveFavorite(userID, assetID string) error {
+// The favorite moves to the user's trash, from which it can be restored
+// until it is purged.
+func (s *MemoryStore) RemoveFavorite(ctx context.Context, userID, assetID string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -103,24 +243,41 @@ func (s *MemoryStore) RemoveFavorite(userID, assetID string) error {
 		if fav.AssetID == assetID {
 			// Remove by swapping with last element and truncating
 			s.users[userID] = append(favorites[:i], favorites[i+1:]...)
-			return nil
+			s.moveToTrash(userID, fav, i)
+			s.deleteMember(userID, assetID)
+
+			delete(s.popularity[assetID], userID)
+			if len(s.popularity[assetID]) == 0 {
+				delete(s.popularity, assetID)
+			}
+			return Removed, nil
 		}
 	}
-	return nil // Not found, but not an error
+	return NotFound, nil // Not found, but not an error
 }
 
 // EditFavoriteDescription edits the user's custom description for a favorite.
-func (s *MemoryStore) EditFavoriteDescription(userID, assetID, desc string) error {
+func (s *MemoryStore) EditFavoriteDescription(ctx context.Context, userID, assetID, desc string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	favorites := s.users[userID]
-	for i, fav := range favorites {
+	if _, ok := s.members[userID][assetID]; !ok {
+		return ErrAssetNotFound
+	}
+	s.edit(userID, assetID, desc)
+	return nil
+}
+
+// edit sets the description of an existing favorite. Caller must hold s.mu.
+func (s *MemoryStore) edit(userID, assetID, desc string) {
+	for i, fav := range s.users[userID] {
 		if fav.AssetID == assetID {
 			s.users[userID][i].Description = desc
-			return nil
+			s.setMember(userID, s.users[userID][i])
+			return
 		}
 	}
-
-	return ErrAssetNotFound
 }
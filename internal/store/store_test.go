@@ -1,7 +1,10 @@
 package store
 
 import (
+	"context"
+	"sort"
 	"testing"
+	"time"
 
 	"my-solution/internal/catalog"
 	"my-solution/internal/models"
@@ -9,7 +12,7 @@ import (
 
 func TestStore_AddListRemoveEdit(t *testing.T) {
 	catalog.Initialize()
-	store := NewMemoryStore()
+	store := Legacy(NewMemoryStore())
 	userID := "user-test"
 
 	// Mock catalog with assets, using asset IDs
@@ -103,3 +106,113 @@ func TestStore_AddListRemoveEdit(t *testing.T) {
 		t.Errorf("expected 2 favorites after remove, got %d", len(favs))
 	}
 }
+
+func TestMemoryStore_PopularityIndex(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+
+	s.AddFavorite(ctx, "u1", "a", "")
+	s.AddFavorite(ctx, "u2", "a", "")
+	s.AddFavorite(ctx, "u1", "b", "")
+	s.AddFavorite(ctx, "u1", "a", "") // duplicate, must not count twice
+
+	counts, err := s.FavoriteCounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["a"] != 2 || counts["b"] != 1 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+
+	users, _ := s.Favoriters(ctx, "a")
+	sort.Strings(users)
+	if len(users) != 2 || users[0] != "u1" || users[1] != "u2" {
+		t.Errorf("unexpected favoriters %v", users)
+	}
+
+	s.RemoveFavorite(ctx, "u1", "b")
+	counts, _ = s.FavoriteCounts(ctx)
+	if _, ok := counts["b"]; ok {
+		t.Errorf("expected b to be dropped from the index, got %v", counts)
+	}
+
+	// Backdate u2's favorite of a so it falls outside the window.
+	s.popularity["a"]["u2"] = time.Now().Add(-48 * time.Hour)
+	times, _ := s.FavoriteTimes(ctx, time.Now().Add(-24*time.Hour))
+	if len(times["a"]) != 1 {
+		t.Errorf("expected 1 recent favorite of a, got %v", times["a"])
+	}
+}
+
+func TestMemoryStore_Trash(t *testing.T) {
+	catalog.Initialize()
+	ctx := context.Background()
+	s := NewMemoryStore()
+	for _, id := range []string{"a", "b", "c"} {
+		s.AddFavorite(ctx, "u1", id, "note "+id)
+	}
+	created := s.users["u1"][1].CreatedAt
+
+	s.RemoveFavorite(ctx, "u1", "b")
+	s.RemoveFavorite(ctx, "u1", "c")
+	trash, _ := s.ListTrash(ctx, "u1")
+	if len(trash) != 2 || trash[0].AssetID != "c" || trash[1].AssetID != "b" {
+		t.Fatalf("expected most recent first, got %+v", trash)
+	}
+	if !trash[1].ExpiresAt.Equal(trash[1].DeletedAt.Add(DefaultTrashRetention)) {
+		t.Errorf("unexpected expiry %v", trash[1].ExpiresAt)
+	}
+	if counts, _ := s.FavoriteCounts(ctx); counts["b"] != 0 {
+		t.Error("trashed favorites should not count toward popularity")
+	}
+
+	if err := s.RestoreFavorite(ctx, "u1", "b"); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	favs := s.users["u1"]
+	if len(favs) != 2 || favs[1].AssetID != "b" || favs[1].Description != "note b" || !favs[1].CreatedAt.Equal(created) {
+		t.Fatalf("restore should keep position and metadata, got %+v", favs)
+	}
+	if err := s.RestoreFavorite(ctx, "u1", "b"); err != ErrAssetNotFound {
+		t.Errorf("expected ErrAssetNotFound, got %v", err)
+	}
+
+	s.AddFavorite(ctx, "u1", "c", "again")
+	if err := s.RestoreFavorite(ctx, "u1", "c"); err != ErrAlreadyFavorited {
+		t.Errorf("expected ErrAlreadyFavorited, got %v", err)
+	}
+
+	if n, _ := s.PurgeTrash(ctx, time.Now()); n != 0 {
+		t.Errorf("nothing should expire yet, purged %d", n)
+	}
+	if n, _ := s.PurgeTrash(ctx, time.Now().Add(DefaultTrashRetention)); n != 1 {
+		t.Errorf("expected 1 purged, got %d", n)
+	}
+	if trash, _ := s.ListTrash(ctx, "u1"); len(trash) != 0 {
+		t.Errorf("trash should be empty, got %+v", trash)
+	}
+}
+
+func TestMemoryStore_MembershipIndex(t *testing.T) {
+	catalog.Initialize()
+	ctx := context.Background()
+	s := NewMemoryStore()
+	s.AddFavorite(ctx, "u1", "a", "note a")
+	s.AddFavorite(ctx, "u1", "b", "")
+	s.AddFavorite(ctx, "u1", "old", "note old")
+
+	s.RemoveFavorite(ctx, "u1", "a")
+	s.RestoreFavorite(ctx, "u1", "a")
+	s.MigrateAsset(ctx, "old", "b")
+
+	got, _ := s.FavoriteDescriptions(ctx, "u1")
+	want := map[string]string{"a": "note a", "b": "note old"}
+	if len(got) != len(want) || got["a"] != want["a"] || got["b"] != want["b"] {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	s.EraseUser(ctx, "u1")
+	if got, _ := s.FavoriteDescriptions(ctx, "u1"); len(got) != 0 {
+		t.Errorf("expected no favorites after erasure, got %v", got)
+	}
+}
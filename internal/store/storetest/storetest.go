@@ -0,0 +1,633 @@
+// Package storetest provides a conformance suite that every store.Store
+// implementation is expected to pass.
+//
+// Backends call Run from their own tests with a factory returning a fresh,
+// empty store:
+//
+//	func TestMemoryStoreConformance(t *testing.T) {
+//		storetest.Run(t, func() store.Store { return store.NewMemoryStore() })
+//	}
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+)
+
+var ctx = context.Background()
+
+// Factory returns a new, empty store for a single subtest.
+type Factory func() store.Store
+
+// Fixture asset IDs seeded into catalog.Global by Run.
+const (
+	ChartID    = "storetest-chart"
+	InsightID  = "storetest-insight"
+	AudienceID = "storetest-audience"
+	MissingID  = "storetest-missing" // never added to the catalog
+)
+
+// Run executes the full conformance suite against stores built by newStore.
+// It resets catalog.Global and seeds it with the fixture assets.
+func Run(t *testing.T, newStore Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(*testing.T, Factory)
+	}{
+		{"AddAndList", testAddAndList},
+		{"ListEmpty", testListEmpty},
+		{"DuplicateAdd", testDuplicateAdd},
+		{"PreservesInsertionOrder", testPreservesInsertionOrder},
+		{"Remove", testRemove},
+		{"RemoveMissing", testRemoveMissing},
+		{"ReAddAfterRemove", testReAddAfterRemove},
+		{"Upsert", testUpsert},
+		{"EditDescription", testEditDescription},
+		{"EditMissing", testEditMissing},
+		{"GetFavorite", testGetFavorite},
+		{"UserIsolation", testUserIsolation},
+		{"CatalogMissingAsset", testCatalogMissingAsset},
+		{"ConcurrentAdds", testConcurrentAdds},
+		{"ConcurrentMixedOperations", testConcurrentMixedOperations},
+		{"CancelledContext", testCancelledContext},
+		{"EraseUser", testEraseUser},
+		{"FavoriteDescriptions", testFavoriteDescriptions},
+		{"ScanFavorites", testScanFavorites},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seedCatalog()
+			tc.fn(t, newStore)
+		})
+	}
+}
+
+// seedCatalog resets the global catalog and adds one asset of each type.
+func seedCatalog() {
+	catalog.Initialize()
+	catalog.Global.AddAsset(ChartID, models.Chart{
+		AssetBase: models.AssetBase{ID: ChartID, Name: "Revenue", Description: "Quarterly revenue"},
+		ChartType: "bar",
+	})
+	catalog.Global.AddAsset(InsightID, models.Insight{
+		AssetBase: models.AssetBase{ID: InsightID, Name: "Engagement", Description: "Social media usage"},
+		Metric:    "Engagement",
+		Value:     "40%",
+	})
+	catalog.Global.AddAsset(AudienceID, models.Audience{
+		AssetBase: models.AssetBase{ID: AudienceID, Name: "Gen Z", Description: "Aged 18-24"},
+		Segment:   "18-24",
+		Size:      12000,
+	})
+}
+
+// addBulkAssets adds n extra charts to the catalog and returns their IDs.
+func addBulkAssets(prefix string, n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		id := fmt.Sprintf("%s-%d", prefix, i)
+		catalog.Global.AddAsset(id, models.Chart{
+			AssetBase: models.AssetBase{ID: id, Name: id},
+			ChartType: "line",
+		})
+		ids[i] = id
+	}
+	return ids
+}
+
+func mustAdd(t *testing.T, s store.Store, userID, assetID, desc string) {
+	t.Helper()
+	if err := s.AddFavorite(ctx, userID, assetID, desc); err != nil {
+		t.Fatalf("AddFavorite(%q, %q): %v", userID, assetID, err)
+	}
+}
+
+func mustList(t *testing.T, s store.Store, userID string) []models.FavoriteWithAsset {
+	t.Helper()
+	favs, err := s.ListFavorites(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListFavorites(%q): %v", userID, err)
+	}
+	return favs
+}
+
+func assetIDs(favs []models.FavoriteWithAsset) []string {
+	ids := make([]string, len(favs))
+	for i, f := range favs {
+		ids[i] = f.AssetID
+	}
+	return ids
+}
+
+func testAddAndList(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "my chart")
+	mustAdd(t, s, "u1", InsightID, "my insight")
+	mustAdd(t, s, "u1", AudienceID, "my audience")
+
+	favs := mustList(t, s, "u1")
+	if len(favs) != 3 {
+		t.Fatalf("expected 3 favorites, got %d", len(favs))
+	}
+	for _, f := range favs {
+		if f.Asset == nil {
+			t.Errorf("favorite %s has no joined asset", f.AssetID)
+			continue
+		}
+		if f.Asset.GetID() != f.AssetID {
+			t.Errorf("joined asset ID %s does not match favorite %s", f.Asset.GetID(), f.AssetID)
+		}
+		if f.CreatedAt.IsZero() {
+			t.Errorf("favorite %s has zero CreatedAt", f.AssetID)
+		}
+	}
+	if favs[0].Description != "my chart" {
+		t.Errorf("expected description %q, got %q", "my chart", favs[0].Description)
+	}
+	if _, ok := favs[0].Asset.(models.Chart); !ok {
+		t.Errorf("expected models.Chart, got %T", favs[0].Asset)
+	}
+}
+
+func testListEmpty(t *testing.T, newStore Factory) {
+	s := newStore()
+	favs := mustList(t, s, "nobody")
+	if favs == nil {
+		t.Error("expected empty non-nil slice, got nil")
+	}
+	if len(favs) != 0 {
+		t.Errorf("expected 0 favorites, got %d", len(favs))
+	}
+}
+
+func testDuplicateAdd(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "first")
+
+	err := s.AddFavorite(ctx, "u1", ChartID, "second")
+	if !errors.Is(err, store.ErrAlreadyFavorited) {
+		t.Fatalf("expected ErrAlreadyFavorited, got %v", err)
+	}
+
+	favs := mustList(t, s, "u1")
+	if len(favs) != 1 {
+		t.Fatalf("expected 1 favorite after duplicate add, got %d", len(favs))
+	}
+	if favs[0].Description != "first" {
+		t.Errorf("duplicate add overwrote description: got %q", favs[0].Description)
+	}
+}
+
+func testPreservesInsertionOrder(t *testing.T, newStore Factory) {
+	s := newStore()
+	want := addBulkAssets("order", 20)
+	for _, id := range want {
+		mustAdd(t, s, "u1", id, "")
+	}
+
+	got := assetIDs(mustList(t, s, "u1"))
+	if len(got) != len(want) {
+		t.Fatalf("expected %d favorites, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order mismatch at %d: want %s, got %s", i, want[i], got[i])
+		}
+	}
+
+	// Removing from the middle must keep the relative order of the rest.
+	if _, err := s.RemoveFavorite(ctx, "u1", want[5]); err != nil {
+		t.Fatalf("RemoveFavorite: %v", err)
+	}
+	want = append(want[:5:5], want[6:]...)
+	got = assetIDs(mustList(t, s, "u1"))
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order mismatch after remove at %d: want %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func testRemove(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "")
+	mustAdd(t, s, "u1", InsightID, "")
+
+	if res, err := s.RemoveFavorite(ctx, "u1", ChartID); err != nil {
+		t.Fatalf("RemoveFavorite: %v", err)
+	} else if res != store.Removed {
+		t.Errorf("expected %v, got %v", store.Removed, res)
+	}
+	favs := mustList(t, s, "u1")
+	if len(favs) != 1 || favs[0].AssetID != InsightID {
+		t.Fatalf("expected only %s to remain, got %v", InsightID, assetIDs(favs))
+	}
+}
+
+func testRemoveMissing(t *testing.T, newStore Factory) {
+	s := newStore()
+	if res, err := s.RemoveFavorite(ctx, "nobody", ChartID); err != nil || res != store.NotFound {
+		t.Errorf("removing from unknown user: expected NotFound, got %v, %v", res, err)
+	}
+
+	mustAdd(t, s, "u1", ChartID, "")
+	if res, err := s.RemoveFavorite(ctx, "u1", InsightID); err != nil || res != store.NotFound {
+		t.Errorf("removing unfavorited asset: expected NotFound, got %v, %v", res, err)
+	}
+	if n := len(mustList(t, s, "u1")); n != 1 {
+		t.Errorf("expected 1 favorite to remain, got %d", n)
+	}
+}
+
+func testReAddAfterRemove(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "old")
+	if _, err := s.RemoveFavorite(ctx, "u1", ChartID); err != nil {
+		t.Fatalf("RemoveFavorite: %v", err)
+	}
+	mustAdd(t, s, "u1", ChartID, "new")
+
+	favs := mustList(t, s, "u1")
+	if len(favs) != 1 || favs[0].Description != "new" {
+		t.Fatalf("expected re-added favorite with description %q, got %+v", "new", favs)
+	}
+}
+
+func testUpsert(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "")
+
+	res, err := s.UpsertFavorite(ctx, "u1", InsightID, "new")
+	if err != nil || res != store.Created {
+		t.Fatalf("upserting new favorite: expected Created, got %v, %v", res, err)
+	}
+	res, err = s.UpsertFavorite(ctx, "u1", ChartID, "changed")
+	if err != nil || res != store.Updated {
+		t.Fatalf("upserting existing favorite: expected Updated, got %v, %v", res, err)
+	}
+
+	favs := mustList(t, s, "u1")
+	if got := assetIDs(favs); len(got) != 2 || got[0] != ChartID || got[1] != InsightID {
+		t.Fatalf("upsert should append new and update in place, got %v", got)
+	}
+	if favs[0].Description != "changed" || favs[1].Description != "new" {
+		t.Errorf("unexpected descriptions %+v", favs)
+	}
+}
+
+func testEditDescription(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "before")
+	mustAdd(t, s, "u1", InsightID, "untouched")
+
+	if err := s.EditFavoriteDescription(ctx, "u1", ChartID, "after"); err != nil {
+		t.Fatalf("EditFavoriteDescription: %v", err)
+	}
+	for _, f := range mustList(t, s, "u1") {
+		switch f.AssetID {
+		case ChartID:
+			if f.Description != "after" {
+				t.Errorf("expected %q, got %q", "after", f.Description)
+			}
+		case InsightID:
+			if f.Description != "untouched" {
+				t.Errorf("edit leaked into %s: got %q", InsightID, f.Description)
+			}
+		}
+	}
+}
+
+func testEditMissing(t *testing.T, newStore Factory) {
+	s := newStore()
+	err := s.EditFavoriteDescription(ctx, "nobody", ChartID, "x")
+	if !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("unknown user: expected ErrAssetNotFound, got %v", err)
+	}
+
+	mustAdd(t, s, "u1", ChartID, "")
+	err = s.EditFavoriteDescription(ctx, "u1", InsightID, "x")
+	if !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("unfavorited asset: expected ErrAssetNotFound, got %v", err)
+	}
+}
+
+func testGetFavorite(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "first")
+	mustAdd(t, s, "u1", InsightID, "second")
+	if err := s.EditFavoriteDescription(ctx, "u1", InsightID, "edited"); err != nil {
+		t.Fatalf("EditFavoriteDescription: %v", err)
+	}
+
+	fav, err := s.GetFavorite(ctx, "u1", InsightID)
+	if err != nil {
+		t.Fatalf("GetFavorite: %v", err)
+	}
+	if fav.AssetID != InsightID || fav.Description != "edited" || fav.CreatedAt.IsZero() || fav.Status != "" {
+		t.Errorf("unexpected favorite %+v", fav)
+	}
+	if _, ok := fav.Asset.(models.Insight); !ok {
+		t.Errorf("expected joined models.Insight, got %T", fav.Asset)
+	}
+
+	if _, err := s.GetFavorite(ctx, "u1", AudienceID); !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("not favorited: expected ErrAssetNotFound, got %v", err)
+	}
+	if _, err := s.GetFavorite(ctx, "u2", ChartID); !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("other user: expected ErrAssetNotFound, got %v", err)
+	}
+	if _, err := s.RemoveFavorite(ctx, "u1", ChartID); err != nil {
+		t.Fatalf("RemoveFavorite: %v", err)
+	}
+	if _, err := s.GetFavorite(ctx, "u1", ChartID); !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("removed: expected ErrAssetNotFound, got %v", err)
+	}
+}
+
+func testUserIsolation(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "alice", ChartID, "alice's")
+
+	if n := len(mustList(t, s, "bob")); n != 0 {
+		t.Errorf("bob should have no favorites, got %d", n)
+	}
+	if err := s.EditFavoriteDescription(ctx, "bob", ChartID, "bob's"); !errors.Is(err, store.ErrAssetNotFound) {
+		t.Errorf("bob editing alice's favorite: expected ErrAssetNotFound, got %v", err)
+	}
+	if _, err := s.RemoveFavorite(ctx, "bob", ChartID); err != nil {
+		t.Errorf("bob removing alice's favorite: %v", err)
+	}
+	mustAdd(t, s, "bob", ChartID, "bob's")
+
+	favs := mustList(t, s, "alice")
+	if len(favs) != 1 || favs[0].Description != "alice's" {
+		t.Errorf("alice's favorites changed: %+v", favs)
+	}
+}
+
+func testCatalogMissingAsset(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "")
+
+	// Stores do not validate against the catalog; the API layer does.
+	// Favorites whose asset is not in the catalog are left out of listings.
+	if err := s.AddFavorite(ctx, "u1", MissingID, ""); err != nil {
+		t.Fatalf("AddFavorite for catalog-missing asset: %v", err)
+	}
+	favs := mustList(t, s, "u1")
+	if len(favs) != 1 || favs[0].AssetID != ChartID {
+		t.Fatalf("expected only %s to be listed, got %v", ChartID, assetIDs(favs))
+	}
+
+	// The reference is still held, so it can be edited and removed.
+	if err := s.EditFavoriteDescription(ctx, "u1", MissingID, "x"); err != nil {
+		t.Errorf("EditFavoriteDescription for catalog-missing asset: %v", err)
+	}
+	if _, err := s.RemoveFavorite(ctx, "u1", MissingID); err != nil {
+		t.Errorf("RemoveFavorite for catalog-missing asset: %v", err)
+	}
+}
+
+func testConcurrentAdds(t *testing.T, newStore Factory) {
+	s := newStore()
+	ids := addBulkAssets("concurrent", 50)
+
+	// Every goroutine tries to add every asset; exactly one add per asset
+	// may succeed.
+	const workers = 8
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		successes = make(map[string]int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, id := range ids {
+				err := s.AddFavorite(ctx, "u1", id, "")
+				if err == nil {
+					mu.Lock()
+					successes[id]++
+					mu.Unlock()
+				} else if !errors.Is(err, store.ErrAlreadyFavorited) {
+					t.Errorf("AddFavorite(%s): %v", id, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if successes[id] != 1 {
+			t.Errorf("asset %s added %d times", id, successes[id])
+		}
+	}
+	if n := len(mustList(t, s, "u1")); n != len(ids) {
+		t.Errorf("expected %d favorites, got %d", len(ids), n)
+	}
+}
+
+func testConcurrentMixedOperations(t *testing.T, newStore Factory) {
+	s := newStore()
+	ids := addBulkAssets("mixed", 20)
+
+	var wg sync.WaitGroup
+	for u := 0; u < 4; u++ {
+		userID := fmt.Sprintf("user-%d", u)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, id := range ids {
+				if err := s.AddFavorite(ctx, userID, id, ""); err != nil {
+					t.Errorf("AddFavorite: %v", err)
+					return
+				}
+				if _, err := s.ListFavorites(ctx, userID); err != nil {
+					t.Errorf("ListFavorites: %v", err)
+					return
+				}
+				if err := s.EditFavoriteDescription(ctx, userID, id, "edited"); err != nil {
+					t.Errorf("EditFavoriteDescription: %v", err)
+					return
+				}
+			}
+			for _, id := range ids[:len(ids)/2] {
+				if _, err := s.RemoveFavorite(ctx, userID, id); err != nil {
+					t.Errorf("RemoveFavorite: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for u := 0; u < 4; u++ {
+		favs := mustList(t, s, fmt.Sprintf("user-%d", u))
+		if len(favs) != len(ids)/2 {
+			t.Errorf("user-%d: expected %d favorites, got %d", u, len(ids)/2, len(favs))
+		}
+		for _, f := range favs {
+			if f.Description != "edited" {
+				t.Errorf("user-%d: %s has description %q", u, f.AssetID, f.Description)
+			}
+		}
+	}
+}
+
+func testCancelledContext(t *testing.T, newStore Factory) {
+	s := newStore()
+	mustAdd(t, s, "u1", ChartID, "")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.AddFavorite(cancelled, "u1", InsightID, ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("AddFavorite: expected context.Canceled, got %v", err)
+	}
+	if _, err := s.ListFavorites(cancelled, "u1"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListFavorites: expected context.Canceled, got %v", err)
+	}
+	if _, err := s.UpsertFavorite(cancelled, "u1", ChartID, "x"); !errors.Is(err, context.Canceled) {
+		t.Errorf("UpsertFavorite: expected context.Canceled, got %v", err)
+	}
+	if _, err := s.GetFavorite(cancelled, "u1", ChartID); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetFavorite: expected context.Canceled, got %v", err)
+	}
+	if _, err := s.RemoveFavorite(cancelled, "u1", ChartID); !errors.Is(err, context.Canceled) {
+		t.Errorf("RemoveFavorite: expected context.Canceled, got %v", err)
+	}
+	if err := s.EditFavoriteDescription(cancelled, "u1", ChartID, "x"); !errors.Is(err, context.Canceled) {
+		t.Errorf("EditFavoriteDescription: expected context.Canceled, got %v", err)
+	}
+
+	// None of the cancelled calls may have taken effect.
+	favs := mustList(t, s, "u1")
+	if len(favs) != 1 || favs[0].AssetID != ChartID || favs[0].Description != "" {
+		t.Errorf("cancelled calls modified the store: %+v", favs)
+	}
+}
+
+// testEraseUser only runs for stores that support store.UserEraser.
+func testEraseUser(t *testing.T, newStore Factory) {
+	s := newStore()
+	eraser, ok := store.Find[store.UserEraser](s)
+	if !ok {
+		t.Skip("store does not support erasure")
+	}
+	mustAdd(t, s, "u1", ChartID, "")
+	mustAdd(t, s, "u1", InsightID, "")
+	mustAdd(t, s, "u2", ChartID, "")
+
+	erased, err := eraser.EraseUser(ctx, "u1")
+	if err != nil {
+		t.Fatalf("EraseUser: %v", err)
+	}
+	if erased["favorites"] != 2 {
+		t.Errorf("expected 2 erased favorites, got %v", erased)
+	}
+	if favs := mustList(t, s, "u1"); len(favs) != 0 {
+		t.Errorf("erased user still has favorites %v", assetIDs(favs))
+	}
+	if favs := mustList(t, s, "u2"); len(favs) != 1 {
+		t.Errorf("other users must be untouched, got %v", assetIDs(favs))
+	}
+	mustAdd(t, s, "u1", ChartID, "")
+}
+
+// testFavoriteDescriptions only runs for stores that support
+// store.MembershipIndex. The index must follow every write.
+func testFavoriteDescriptions(t *testing.T, newStore Factory) {
+	s := newStore()
+	idx, ok := store.Find[store.MembershipIndex](s)
+	if !ok {
+		t.Skip("store does not support membership index")
+	}
+	mustAdd(t, s, "u1", ChartID, "mine")
+	mustAdd(t, s, "u1", InsightID, "")
+	mustAdd(t, s, "u2", AudienceID, "")
+	if err := s.EditFavoriteDescription(ctx, "u1", InsightID, "edited"); err != nil {
+		t.Fatalf("EditFavoriteDescription: %v", err)
+	}
+	if _, err := s.RemoveFavorite(ctx, "u1", ChartID); err != nil {
+		t.Fatalf("RemoveFavorite: %v", err)
+	}
+
+	got, err := idx.FavoriteDescriptions(ctx, "u1")
+	if err != nil {
+		t.Fatalf("FavoriteDescriptions: %v", err)
+	}
+	if len(got) != 1 || got[InsightID] != "edited" {
+		t.Errorf("expected only %s with its edited description, got %v", InsightID, got)
+	}
+	if got, _ := idx.FavoriteDescriptions(ctx, "nobody"); len(got) != 0 {
+		t.Errorf("expected no favorites for unknown user, got %v", got)
+	}
+}
+
+// testScanFavorites only runs for stores that support
+// store.FavoriteScanner. A scan must match ListFavorites across batch
+// boundaries and keep going when favorites are removed under it.
+func testScanFavorites(t *testing.T, newStore Factory) {
+	s := newStore()
+	scanner, ok := store.Find[store.FavoriteScanner](s)
+	if !ok {
+		t.Skip("store does not support scanning")
+	}
+	for _, id := range addBulkAssets("scan", store.ScanBatch+10) {
+		mustAdd(t, s, "u1", id, "")
+	}
+	mustAdd(t, s, "u1", MissingID, "")
+
+	var got []models.FavoriteWithAsset
+	err := scanner.ScanFavorites(ctx, "u1", func(fav models.FavoriteWithAsset) error {
+		got = append(got, fav)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanFavorites: %v", err)
+	}
+	if want := assetIDs(mustList(t, s, "u1")); !slices.Equal(assetIDs(got), want) {
+		t.Fatalf("scan returned %d favorites, list %d", len(got), len(want))
+	}
+
+	// Removing the last favorite of a batch while it is being reported
+	// must not stop or restart the scan.
+	boundary := got[store.ScanBatch-1].AssetID
+	var ids []string
+	err = scanner.ScanFavorites(ctx, "u1", func(fav models.FavoriteWithAsset) error {
+		ids = append(ids, fav.AssetID)
+		if fav.AssetID == boundary {
+			_, err := s.RemoveFavorite(ctx, "u1", boundary)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ScanFavorites: %v", err)
+	}
+	if want := assetIDs(got); !slices.Equal(ids, want) {
+		t.Errorf("scan with removal returned %d favorites, want %d", len(ids), len(want))
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	err = scanner.ScanFavorites(ctx, "u1", func(models.FavoriteWithAsset) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("expected the scan to stop at the first error, got %v after %d", err, n)
+	}
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"my-solution/internal/catalog"
+	"my-solution/internal/models"
+)
+
+// Trash is implemented by stores that soft-delete removed favorites.
+type Trash interface {
+	// ListTrash returns the user's removed favorites, most recently
+	// removed first, joined with the catalog like ListFavorites.
+	ListTrash(ctx context.Context, userID string) ([]models.TrashedFavorite, error)
+
+	// RestoreFavorite moves a favorite out of the trash and back into the
+	// user's list with its original description, CreatedAt and position.
+	// It returns ErrAssetNotFound if the asset is not in the trash and
+	// ErrAlreadyFavorited if the user has favorited it again since.
+	RestoreFavorite(ctx context.Context, userID, assetID string) error
+
+	// PurgeTrash permanently deletes trashed favorites whose retention
+	// period ended before now, returning how many were deleted.
+	PurgeTrash(ctx context.Context, now time.Time) (int, error)
+}
+
+// trashEntry is a removed favorite and where it used to be.
+type trashEntry struct {
+	fav       models.Favorite
+	position  int // Index in the user's list at removal time
+	deletedAt time.Time
+}
+
+// moveToTrash records a removed favorite. A favorite trashed again
+// replaces its earlier trash entry. Caller must hold s.mu.
+func (s *MemoryStore) moveToTrash(userID string, fav models.Favorite, position int) {
+	entries := s.trash[userID]
+	for i, e := range entries {
+		if e.fav.AssetID == fav.AssetID {
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	s.trash[userID] = append(entries, trashEntry{fav: fav, position: position, deletedAt: time.Now()})
+}
+
+// ListTrash returns the user's removed favorites, most recently removed first.
+func (s *MemoryStore) ListTrash(ctx context.Context, userID string) ([]models.TrashedFavorite, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.trash[userID]
+	result := make([]models.TrashedFavorite, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		item := models.TrashedFavorite{
+			AssetID:     e.fav.AssetID,
+			Description: e.fav.Description,
+			CreatedAt:   e.fav.CreatedAt,
+			DeletedAt:   e.deletedAt,
+			ExpiresAt:   e.deletedAt.Add(s.TrashRetention),
+		}
+		asset, ok := catalog.Global.Get(e.fav.AssetID)
+		if !ok {
+			item.Status = models.StatusUnavailable
+		}
+		item.Asset = asset
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// RestoreFavorite moves a favorite from the trash back into the user's list.
+func (s *MemoryStore) RestoreFavorite(ctx context.Context, userID, assetID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.trash[userID]
+	idx := -1
+	for i, e := range entries {
+		if e.fav.AssetID == assetID {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return ErrAssetNotFound
+	}
+	if _, ok := s.members[userID][assetID]; ok {
+		return ErrAlreadyFavorited
+	}
+
+	e := entries[idx]
+	s.trash[userID] = append(entries[:idx], entries[idx+1:]...)
+	if len(s.trash[userID]) == 0 {
+		delete(s.trash, userID)
+	}
+
+	// The list may have shrunk since; clamp to the end.
+	favorites := s.users[userID]
+	pos := min(e.position, len(favorites))
+	favorites = append(favorites, models.Favorite{})
+	copy(favorites[pos+1:], favorites[pos:])
+	favorites[pos] = e.fav
+	s.users[userID] = favorites
+	s.setMember(userID, e.fav)
+
+	if s.popularity[assetID] == nil {
+		s.popularity[assetID] = make(map[string]time.Time)
+	}
+	s.popularity[assetID][userID] = e.fav.CreatedAt
+	return nil
+}
+
+// PurgeTrash deletes trashed favorites older than the retention period.
+func (s *MemoryStore) PurgeTrash(ctx context.Context, now time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := 0
+	for userID, entries := range s.trash {
+		kept := entries[:0]
+		for _, e := range entries {
+			if now.Before(e.deletedAt.Add(s.TrashRetention)) {
+				kept = append(kept, e)
+			}
+		}
+		purged += len(entries) - len(kept)
+		if len(kept) == 0 {
+			delete(s.trash, userID)
+		} else {
+			s.trash[userID] = kept
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,44 @@
+package store
+
+// Wrapper is implemented by decorators around a Store so that optional
+// capabilities of the underlying store (such as PopularityIndex) remain
+// reachable through Find.
+type Wrapper interface {
+	Unwrap() Store
+}
+
+// Forwarder is implemented by decorators that implement some optional
+// interfaces only by forwarding to the store they wrap, returning
+// ErrUnsupported when it lacks them. Forwards is called with a nil
+// pointer to the interface being looked up, e.g. (*Trash)(nil), and
+// reports whether the decorator merely forwards it.
+type Forwarder interface {
+	Wrapper
+	Forwards(capability any) bool
+}
+
+// Find returns the first store in the decorator chain starting at s that
+// implements T. Calls made through the result bypass the decorators above
+// it, so it should be used for read-only capabilities. A Forwarder that
+// forwards T is only returned if the store it wraps provides T.
+func Find[T any](s Store) (T, bool) {
+	var zero T
+	for s != nil {
+		if t, ok := s.(T); ok {
+			f, ok := s.(Forwarder)
+			if !ok || !f.Forwards((*T)(nil)) {
+				return t, true
+			}
+			if _, ok := Find[T](f.Unwrap()); !ok {
+				return zero, false
+			}
+			return t, true
+		}
+		w, ok := s.(Wrapper)
+		if !ok {
+			break
+		}
+		s = w.Unwrap()
+	}
+	return zero, false
+}
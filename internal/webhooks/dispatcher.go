@@ -0,0 +1,442 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"my-solution/internal/events"
+)
+
+// Payload is the JSON body POSTed to subscribers.
+type Payload struct {
+	ID        string       `json:"id"` // Delivery ID; stable across retries
+	Type      events.Type  `json:"type"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Data      events.Event `json:"data"`
+}
+
+// DeliveryState is the lifecycle state of a delivery.
+type DeliveryState string
+
+const (
+	StatePending   DeliveryState = "pending"
+	StateSucceeded DeliveryState = "succeeded"
+	StateDead      DeliveryState = "dead"
+)
+
+// Delivery is one event destined for one subscription.
+type Delivery struct {
+	ID             string        `json:"id"`
+	SubscriptionID string        `json:"subscriptionId"`
+	Event          events.Event  `json:"event"`
+	State          DeliveryState `json:"state"`
+	Attempts       int           `json:"attempts"`
+	LastStatus     int           `json:"lastStatus,omitempty"`
+	LastError      string        `json:"lastError,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt"`
+
+	cancelled bool // Set by EraseUser during an attempt or retry wait; guarded by Dispatcher.mu
+}
+
+// Attempt is a delivery log entry for a single HTTP request.
+type Attempt struct {
+	DeliveryID     string        `json:"deliveryId"`
+	SubscriptionID string        `json:"subscriptionId"`
+	EventType      events.Type   `json:"eventType"`
+	Attempt        int           `json:"attempt"`
+	StatusCode     int           `json:"statusCode,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	Success        bool          `json:"success"`
+	Duration       time.Duration `json:"durationNs" swaggertype:"integer"`
+	Time           time.Time     `json:"time"`
+}
+
+// LogFilter selects entries from the delivery log.
+type LogFilter struct {
+	SubscriptionID string
+	FailedOnly     bool
+	Limit          int // Most recent entries first; 0 means no limit
+}
+
+// Options configures a Dispatcher. Zero values select the defaults.
+type Options struct {
+	Client      *http.Client
+	Workers     int           // Concurrent deliveries (default 4)
+	MaxAttempts int           // Attempts before dead-lettering (default 5)
+	BaseBackoff time.Duration // Delay before the first retry (default 1s)
+	MaxBackoff  time.Duration // Upper bound on retry delay (default 5m)
+	LogSize     int           // Attempts kept in the delivery log (default 1000)
+}
+
+func (o *Options) setDefaults() {
+	if o.Client == nil {
+		o.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if o.Workers <= 0 {
+		o.Workers = 4
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Minute
+	}
+	if o.LogSize <= 0 {
+		o.LogSize = 1000
+	}
+}
+
+// Dispatcher fans events out to matching subscriptions and delivers them
+// in the background.
+type Dispatcher struct {
+	Registry *Registry
+	opts     Options
+
+	mu       sync.Mutex
+	queue    []*Delivery
+	inflight map[*Delivery]struct{}
+	log      []Attempt // Oldest first, at most opts.LogSize entries
+	dead     map[string]*Delivery
+	timers   map[*time.Timer]*Delivery // Deliveries waiting to be retried
+	stopped  bool
+
+	wake chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher for the subscriptions in reg.
+// Call Start to begin delivering.
+func NewDispatcher(reg *Registry, opts Options) *Dispatcher {
+	opts.setDefaults()
+	return &Dispatcher{
+		Registry: reg,
+		opts:     opts,
+		inflight: make(map[*Delivery]struct{}),
+		dead:     make(map[string]*Delivery),
+		timers:   make(map[*time.Timer]*Delivery),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Start launches the delivery workers. They stop when ctx is done;
+// call Wait to block until in-flight deliveries have finished.
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := 0; i < d.opts.Workers; i++ {
+		d.wg.Add(1)
+		go d.worker(ctx)
+	}
+	go func() {
+		<-ctx.Done()
+		d.mu.Lock()
+		d.stopped = true
+		for t := range d.timers {
+			t.Stop()
+		}
+		d.mu.Unlock()
+	}()
+}
+
+// Wait blocks until all workers have exited.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+// Enqueue queues e for every matching subscription. It never blocks and
+// is suitable as an events.Bus listener. Events published by a user
+// erasure are dropped, so that the erased user's ID is not sent out.
+func (d *Dispatcher) Enqueue(e events.Event) {
+	if e.Erasure {
+		return
+	}
+	subs := d.Registry.matching(e.Type)
+	if len(subs) == 0 {
+		return
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	for _, sub := range subs {
+		d.queue = append(d.queue, &Delivery{
+			ID:             newID(),
+			SubscriptionID: sub.ID,
+			Event:          e,
+			State:          StatePending,
+			CreatedAt:      now,
+		})
+	}
+	d.mu.Unlock()
+	d.signal()
+}
+
+func (d *Dispatcher) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) next() *Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.queue) == 0 {
+		return nil
+	}
+	del := d.queue[0]
+	d.queue[0] = nil
+	d.queue = d.queue[1:]
+	d.inflight[del] = struct{}{}
+	if len(d.queue) > 0 {
+		// Let another worker pick up the rest.
+		d.signal()
+	}
+	return del
+}
+
+func (d *Dispatcher) worker(ctx context.Context) {
+	defer d.wg.Done()
+	for {
+		if del := d.next(); del != nil {
+			d.attempt(ctx, del)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		}
+	}
+}
+
+// attempt performs one delivery attempt and schedules a retry, records
+// success, or dead-letters the delivery.
+func (d *Dispatcher) attempt(ctx context.Context, del *Delivery) {
+	sub, err := d.Registry.Get(del.SubscriptionID)
+	if err != nil {
+		// Subscription deleted while the delivery was pending.
+		d.mu.Lock()
+		delete(d.inflight, del)
+		d.mu.Unlock()
+		return
+	}
+
+	del.Attempts++
+	start := time.Now()
+	status, err := d.post(ctx, sub, del)
+	entry := Attempt{
+		DeliveryID:     del.ID,
+		SubscriptionID: sub.ID,
+		EventType:      del.Event.Type,
+		Attempt:        del.Attempts,
+		StatusCode:     status,
+		Success:        err == nil,
+		Duration:       time.Since(start),
+		Time:           start,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	del.LastStatus = status
+	del.LastError = entry.Error
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.inflight, del)
+	d.log = append(d.log, entry)
+	if len(d.log) > d.opts.LogSize {
+		d.log = d.log[len(d.log)-d.opts.LogSize:]
+	}
+
+	switch {
+	case err == nil:
+		del.State = StateSucceeded
+	case del.cancelled:
+		// The user was erased during the attempt; neither retry nor keep it.
+	case !retryable(status) || del.Attempts >= d.opts.MaxAttempts:
+		del.State = StateDead
+		d.dead[del.ID] = del
+	case d.stopped:
+		// Shutting down; the retry is lost along with the in-memory queue.
+	default:
+		var t *time.Timer
+		t = time.AfterFunc(d.backoff(del.Attempts), func() {
+			d.mu.Lock()
+			delete(d.timers, t)
+			if !del.cancelled {
+				d.queue = append(d.queue, del)
+			}
+			d.mu.Unlock()
+			d.signal()
+		})
+		d.timers[t] = del
+	}
+}
+
+// backoff returns the delay before retry number attempt (1-based).
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.opts.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.opts.MaxBackoff {
+			return d.opts.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// retryable reports whether a failed attempt with the given status should
+// be retried. Status 0 means the request itself failed.
+func retryable(status int) bool {
+	switch {
+	case status == 0, status >= 500:
+		return true
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests:
+		return true
+	}
+	return false
+}
+
+func (d *Dispatcher) post(ctx context.Context, sub Subscription, del *Delivery) (int, error) {
+	body, err := json.Marshal(Payload{
+		ID:        del.ID,
+		Type:      del.Event.Type,
+		CreatedAt: del.CreatedAt,
+		Data:      del.Event,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, del.ID)
+	req.Header.Set(HeaderEventType, string(del.Event.Type))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(sub.Secret, ts, body))
+
+	resp, err := d.opts.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Log returns delivery log entries matching f, most recent first.
+func (d *Dispatcher) Log(f LogFilter) []Attempt {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]Attempt, 0)
+	for i := len(d.log) - 1; i >= 0; i-- {
+		a := d.log[i]
+		if f.SubscriptionID != "" && a.SubscriptionID != f.SubscriptionID {
+			continue
+		}
+		if f.FailedOnly && a.Success {
+			continue
+		}
+		result = append(result, a)
+		if f.Limit > 0 && len(result) == f.Limit {
+			break
+		}
+	}
+	return result
+}
+
+// DeadLetters returns deliveries that exhausted their attempts.
+func (d *Dispatcher) DeadLetters() []Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]Delivery, 0, len(d.dead))
+	for _, del := range d.dead {
+		result = append(result, *del)
+	}
+	return result
+}
+
+// EraseUser drops every delivery of the user's events and returns how
+// many were dead-lettered and how many were still pending: queued,
+// waiting to be retried, or in flight. An attempt already in flight
+// cannot be recalled, but it is not retried or dead-lettered if it fails.
+func (d *Dispatcher) EraseUser(userID string) (deadLetters, pending int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, del := range d.dead {
+		if del.Event.UserID == userID {
+			delete(d.dead, id)
+			deadLetters++
+		}
+	}
+
+	queue := d.queue[:0]
+	for _, del := range d.queue {
+		if del.Event.UserID == userID {
+			pending++
+			continue
+		}
+		queue = append(queue, del)
+	}
+	for i := len(queue); i < len(d.queue); i++ {
+		d.queue[i] = nil
+	}
+	d.queue = queue
+
+	for t, del := range d.timers {
+		if del.Event.UserID == userID {
+			// If the timer has already fired, its callback sees cancelled.
+			del.cancelled = true
+			if t.Stop() {
+				delete(d.timers, t)
+			}
+			pending++
+		}
+	}
+	for del := range d.inflight {
+		if del.Event.UserID == userID {
+			del.cancelled = true
+			pending++
+		}
+	}
+	return deadLetters, pending
+}
+
+// Redeliver moves a dead-lettered delivery back onto the queue with a
+// fresh attempt budget.
+func (d *Dispatcher) Redeliver(id string) error {
+	d.mu.Lock()
+	del, ok := d.dead[id]
+	if !ok {
+		d.mu.Unlock()
+		return ErrDeliveryNotFound
+	}
+	delete(d.dead, id)
+	del.State = StatePending
+	del.Attempts = 0
+	d.queue = append(d.queue, del)
+	d.mu.Unlock()
+
+	d.signal()
+	return nil
+}
@@ -0,0 +1,260 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"my-solution/internal/events"
+)
+
+// receiver is a local webhook endpoint that fails the first failN requests
+// with status failWith.
+type receiver struct {
+	mu       sync.Mutex
+	failN    int
+	failWith int
+	got      []Payload
+	headers  []http.Header
+	bodies   [][]byte
+	received chan struct{}
+}
+
+func newReceiver(t *testing.T, failN, failWith int) (*receiver, string) {
+	rc := &receiver{failN: failN, failWith: failWith, received: make(chan struct{}, 100)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		defer func() { rc.received <- struct{}{} }()
+		if rc.failN > 0 {
+			rc.failN--
+			w.WriteHeader(rc.failWith)
+			return
+		}
+		var p Payload
+		json.Unmarshal(body, &p)
+		rc.got = append(rc.got, p)
+		rc.headers = append(rc.headers, r.Header.Clone())
+		rc.bodies = append(rc.bodies, body)
+	}))
+	t.Cleanup(srv.Close)
+	return rc, srv.URL
+}
+
+func (rc *receiver) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-rc.received:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for request %d of %d", i+1, n)
+		}
+	}
+}
+
+func startDispatcher(t *testing.T, maxAttempts int) *Dispatcher {
+	t.Helper()
+	d := NewDispatcher(NewRegistry(), Options{
+		MaxAttempts: maxAttempts,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Start(ctx)
+	t.Cleanup(func() { cancel(); d.Wait() })
+	return d
+}
+
+// eventually polls cond until it holds or a deadline passes.
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	sig := Sign("s3cret", 1700000000, []byte(`{"a":1}`))
+	if !Verify("s3cret", 1700000000, []byte(`{"a":1}`), sig) {
+		t.Error("valid signature rejected")
+	}
+	if Verify("other", 1700000000, []byte(`{"a":1}`), sig) {
+		t.Error("signature with wrong secret accepted")
+	}
+	if Verify("s3cret", 1700000001, []byte(`{"a":1}`), sig) {
+		t.Error("signature with wrong timestamp accepted")
+	}
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	tests := []struct {
+		sub  Subscription
+		want error
+	}{
+		{Subscription{URL: "https://example.com/hook", Secret: "s"}, nil},
+		{Subscription{URL: "ftp://example.com", Secret: "s"}, ErrInvalidURL},
+		{Subscription{URL: "/relative", Secret: "s"}, ErrInvalidURL},
+		{Subscription{URL: "http://example.com"}, ErrSecretRequired},
+		{Subscription{URL: "http://example.com", Secret: "s", EventTypes: []events.Type{"nope"}}, ErrUnknownEventType},
+	}
+	for _, tc := range tests {
+		if err := tc.sub.Validate(); err != tc.want {
+			t.Errorf("%+v: expected %v, got %v", tc.sub, tc.want, err)
+		}
+	}
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	rc, url := newReceiver(t, 0, 0)
+	d := startDispatcher(t, 3)
+	sub, err := d.Registry.Create(Subscription{URL: url, Secret: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d.Enqueue(events.Event{Seq: 1, Type: events.FavoriteAdded, UserID: "u", AssetID: "a"})
+	rc.wait(t, 1)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	h := rc.headers[0]
+	ts, _ := strconv.ParseInt(h.Get(HeaderTimestamp), 10, 64)
+	if !Verify(sub.Secret, ts, rc.bodies[0], h.Get(HeaderSignature)) {
+		t.Error("delivery signature does not verify")
+	}
+	if h.Get(HeaderEventType) != string(events.FavoriteAdded) || h.Get(HeaderDeliveryID) != rc.got[0].ID {
+		t.Errorf("unexpected headers %v", h)
+	}
+	if rc.got[0].Data.AssetID != "a" || rc.got[0].Data.UserID != "u" {
+		t.Errorf("unexpected payload %+v", rc.got[0])
+	}
+}
+
+func TestDispatcher_EventTypeFilter(t *testing.T) {
+	rc, url := newReceiver(t, 0, 0)
+	d := startDispatcher(t, 3)
+	d.Registry.Create(Subscription{URL: url, Secret: "s", EventTypes: []events.Type{events.FavoriteRemoved}})
+
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u"})
+	d.Enqueue(events.Event{Type: events.FavoriteRemoved, UserID: "u"})
+	rc.wait(t, 1)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.got) != 1 || rc.got[0].Type != events.FavoriteRemoved {
+		t.Errorf("expected only the removed event, got %+v", rc.got)
+	}
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	rc, url := newReceiver(t, 2, http.StatusServiceUnavailable)
+	d := startDispatcher(t, 5)
+	sub, _ := d.Registry.Create(Subscription{URL: url, Secret: "s"})
+
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u"})
+	rc.wait(t, 3)
+
+	eventually(t, func() bool { return len(d.Log(LogFilter{SubscriptionID: sub.ID})) == 3 })
+	log := d.Log(LogFilter{SubscriptionID: sub.ID})
+	if !log[0].Success || log[0].Attempt != 3 {
+		t.Errorf("expected third attempt to succeed, got %+v", log[0])
+	}
+	if failed := d.Log(LogFilter{SubscriptionID: sub.ID, FailedOnly: true}); len(failed) != 2 || failed[0].StatusCode != 503 {
+		t.Errorf("expected 2 failed attempts with 503, got %+v", failed)
+	}
+	if n := len(d.DeadLetters()); n != 0 {
+		t.Errorf("expected no dead letters, got %d", n)
+	}
+}
+
+func TestDispatcher_DeadLetterAndRedeliver(t *testing.T) {
+	rc, url := newReceiver(t, 3, http.StatusInternalServerError)
+	d := startDispatcher(t, 3)
+	d.Registry.Create(Subscription{URL: url, Secret: "s"})
+
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u"})
+	rc.wait(t, 3)
+	eventually(t, func() bool { return len(d.DeadLetters()) == 1 })
+
+	dead := d.DeadLetters()[0]
+	if dead.State != StateDead || dead.Attempts != 3 || dead.LastStatus != 500 {
+		t.Errorf("unexpected dead letter %+v", dead)
+	}
+
+	if err := d.Redeliver(dead.ID); err != nil {
+		t.Fatal(err)
+	}
+	rc.wait(t, 1)
+	eventually(t, func() bool { return len(d.DeadLetters()) == 0 })
+	if err := d.Redeliver(dead.ID); err != ErrDeliveryNotFound {
+		t.Errorf("expected ErrDeliveryNotFound, got %v", err)
+	}
+}
+
+func TestDispatcher_ClientErrorsAreNotRetried(t *testing.T) {
+	rc, url := newReceiver(t, 1, http.StatusBadRequest)
+	d := startDispatcher(t, 5)
+	d.Registry.Create(Subscription{URL: url, Secret: "s"})
+
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u"})
+	rc.wait(t, 1)
+	eventually(t, func() bool { return len(d.DeadLetters()) == 1 })
+	if a := d.DeadLetters()[0].Attempts; a != 1 {
+		t.Errorf("expected 1 attempt, got %d", a)
+	}
+}
+
+func TestDispatcher_EraseUserCancelsPendingDeliveries(t *testing.T) {
+	rc, url := newReceiver(t, 1, http.StatusInternalServerError)
+	d := NewDispatcher(NewRegistry(), Options{BaseBackoff: time.Hour})
+	d.Registry.Create(Subscription{URL: url, Secret: "s"})
+
+	// The first attempt fails and waits an hour to be retried.
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u1", AssetID: "a"})
+	d.attempt(context.Background(), d.next())
+	rc.wait(t, 1)
+
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u1", AssetID: "b"})
+	d.Enqueue(events.Event{Type: events.FavoriteRemoved, UserID: "u1", AssetID: "b", Erasure: true})
+	d.Enqueue(events.Event{Type: events.FavoriteAdded, UserID: "u2", AssetID: "a"})
+
+	dead, pending := d.EraseUser("u1")
+	if dead != 0 || pending != 2 {
+		t.Errorf("expected 0 dead letters and 2 pending deliveries, got %d and %d", dead, pending)
+	}
+	if len(d.timers) != 0 {
+		t.Errorf("expected the retry to be cancelled, %d timers left", len(d.timers))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.Start(ctx)
+	defer func() { cancel(); d.Wait() }()
+	rc.wait(t, 1)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.got) != 1 || rc.got[0].Data.UserID != "u2" {
+		t.Errorf("expected only u2's event to be delivered, got %+v", rc.got)
+	}
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(NewRegistry(), Options{BaseBackoff: time.Second, MaxBackoff: 5 * time.Second})
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := d.backoff(i + 1); got != w {
+			t.Errorf("attempt %d: want %v, got %v", i+1, w, got)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package webhooks delivers favorite change events to subscribed HTTP
+// endpoints.
+//
+// Each delivery is a JSON POST signed with HMAC-SHA256 using the
+// subscription's secret. Failed deliveries are retried with exponential
+// backoff; deliveries that exhaust their attempts are moved to a
+// dead-letter queue from which they can be redelivered.
+package webhooks
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"my-solution/internal/events"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrInvalidURL           = errors.New("webhook URL must be an absolute http or https URL")
+	ErrSecretRequired       = errors.New("webhook secret is required")
+	ErrUnknownEventType     = errors.New("unknown event type")
+)
+
+// EventTypes lists the event types a subscription may filter on.
+var EventTypes = []events.Type{events.FavoriteAdded, events.FavoriteRemoved, events.DescriptionEdited}
+
+// Subscription is a registered webhook endpoint.
+type Subscription struct {
+	ID         string        `json:"id"`
+	URL        string        `json:"url"`
+	EventTypes []events.Type `json:"eventTypes"` // Empty means all event types
+	Secret     string        `json:"-"`          // Never echoed back to clients
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+// Matches reports whether the subscription wants events of type t.
+func (s Subscription) Matches(t events.Type) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, et := range s.EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the subscription's URL, secret and event types.
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidURL
+	}
+	if s.Secret == "" {
+		return ErrSecretRequired
+	}
+	for _, t := range s.EventTypes {
+		if !knownType(t) {
+			return ErrUnknownEventType
+		}
+	}
+	return nil
+}
+
+func knownType(t events.Type) bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry holds webhook subscriptions in memory.
+type Registry struct {
+	mu   sync.RWMutex
+	subs map[string]Subscription
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{subs: make(map[string]Subscription)}
+}
+
+// Create validates sub, assigns it an ID and stores it.
+func (r *Registry) Create(sub Subscription) (Subscription, error) {
+	if err := sub.Validate(); err != nil {
+		return Subscription{}, err
+	}
+	sub.ID = newID()
+	sub.CreatedAt = time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs[sub.ID] = sub
+	return sub, nil
+}
+
+// Get returns the subscription with the given ID.
+func (r *Registry) Get(id string) (Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sub, ok := r.subs[id]
+	if !ok {
+		return Subscription{}, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// List returns all subscriptions, oldest first.
+func (r *Registry) List() []Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Subscription, 0, len(r.subs))
+	for _, sub := range r.subs {
+		result = append(result, sub)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.Before(result[j].CreatedAt) })
+	return result
+}
+
+// Delete removes a subscription. Deliveries already queued for it are
+// dropped when they are next attempted.
+func (r *Registry) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subs[id]; !ok {
+		return ErrSubscriptionNotFound
+	}
+	delete(r.subs, id)
+	return nil
+}
+
+// matching returns the subscriptions interested in events of type t.
+func (r *Registry) matching(t events.Type) []Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []Subscription
+	for _, sub := range r.subs {
+		if sub.Matches(t) {
+			result = append(result, sub)
+		}
+	}
+	return result
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
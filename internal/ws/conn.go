@@ -0,0 +1,355 @@
+// Package ws implements the WebSocket protocol (RFC 6455): the opening
+// handshake, framing, fragmentation and control frames. It is primarily a
+// server implementation; Dial provides a minimal client for tests and
+// tooling. Extensions and subprotocol negotiation are not supported.
+package ws
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Opcodes defined by RFC 6455 section 5.2.
+const (
+	OpContinuation = 0x0
+	OpText         = 0x1
+	OpBinary       = 0x2
+	OpClose        = 0x8
+	OpPing         = 0x9
+	OpPong         = 0xA
+)
+
+// Close status codes used by this package and its callers.
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseUnsupportedData = 1003
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+	CloseTryAgainLater   = 1013
+
+	// CloseNoStatus and CloseAbnormal are reported in CloseError for a
+	// close frame without a code and a connection lost without one. RFC
+	// 6455 section 7.4.1 forbids sending them.
+	CloseNoStatus = 1005
+	CloseAbnormal = 1006
+)
+
+// handshakeGUID is appended to Sec-WebSocket-Key to compute the accept value.
+const handshakeGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// DefaultMaxMessageSize bounds the size of a reassembled message.
+const DefaultMaxMessageSize = 64 << 10
+
+var (
+	ErrBadHandshake    = errors.New("ws: bad handshake")
+	ErrMessageTooBig   = errors.New("ws: message too big")
+	ErrProtocol        = errors.New("ws: protocol error")
+	ErrCloseSent       = errors.New("ws: close already sent")
+	errControlTooLarge = errors.New("ws: control frame payload too large")
+)
+
+// CloseError is returned by ReadMessage when the peer sends a close frame.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("ws: closed by peer (%d %s)", e.Code, e.Reason)
+}
+
+// Conn is a WebSocket connection.
+//
+// ReadMessage must be called from a single goroutine. Write methods are
+// safe for concurrent use.
+type Conn struct {
+	conn net.Conn
+	br   *bufio.Reader
+
+	// MaxMessageSize limits the size of a single (reassembled) message.
+	MaxMessageSize int64
+
+	// PongHandler, if set, is called from ReadMessage for every pong frame.
+	PongHandler func(payload []byte)
+
+	client    bool // Client connections mask outgoing frames
+	writeMu   sync.Mutex
+	closeSent bool
+}
+
+// Upgrade performs the opening handshake and takes over the connection.
+// On failure an HTTP error has already been written to w.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "websocket upgrade required", http.StatusUpgradeRequired)
+		return nil, ErrBadHandshake
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, ErrBadHandshake
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, ErrBadHandshake
+	}
+	netConn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+	if _, err := netConn.Write([]byte(resp)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	// The hijacked connection must not carry deadlines from the HTTP server.
+	netConn.SetDeadline(time.Time{})
+
+	return &Conn{conn: netConn, br: rw.Reader, MaxMessageSize: DefaultMaxMessageSize}, nil
+}
+
+// AcceptKey computes the Sec-WebSocket-Accept value for a client key.
+func AcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + handshakeGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, part := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetReadDeadline sets the deadline for the next frame read.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// ReadMessage returns the next text or binary message, transparently
+// reassembling fragments and handling control frames. Pings are answered
+// automatically. A close frame from the peer is echoed and reported as a
+// *CloseError.
+func (c *Conn) ReadMessage() (opcode int, payload []byte, err error) {
+	var msg []byte
+	msgOp := -1
+	for {
+		fin, op, data, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case OpPing:
+			if err := c.writeFrame(OpPong, data, time.Now().Add(time.Second)); err != nil && !errors.Is(err, ErrCloseSent) {
+				return 0, nil, err
+			}
+			continue
+		case OpPong:
+			if c.PongHandler != nil {
+				c.PongHandler(data)
+			}
+			continue
+		case OpClose:
+			ce := &CloseError{Code: CloseNoStatus}
+			if len(data) >= 2 {
+				ce.Code = int(binary.BigEndian.Uint16(data))
+				ce.Reason = string(data[2:])
+			}
+			c.WriteClose(ce.Code, "")
+			return 0, nil, ce
+		case OpText, OpBinary:
+			if msgOp != -1 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+			msgOp = op
+		case OpContinuation:
+			if msgOp == -1 {
+				return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+		}
+
+		if int64(len(msg)+len(data)) > c.MaxMessageSize {
+			return 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooBig)
+		}
+		msg = append(msg, data...)
+		if fin {
+			return msgOp, msg, nil
+		}
+	}
+}
+
+// fail sends a close frame with code and returns err.
+func (c *Conn) fail(code int, err error) error {
+	c.WriteClose(code, err.Error())
+	return err
+}
+
+func (c *Conn) readFrame() (fin bool, op int, payload []byte, err error) {
+	var hdr [2]byte
+	if _, err = io.ReadFull(c.br, hdr[:]); err != nil {
+		return
+	}
+	fin = hdr[0]&0x80 != 0
+	if hdr[0]&0x70 != 0 {
+		// No extensions were negotiated, so RSV bits must be zero.
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+	op = int(hdr[0] & 0x0F)
+	masked := hdr[1]&0x80 != 0
+	length := int64(hdr[1] & 0x7F)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	isControl := op&0x8 != 0
+	if isControl && (length > 125 || !fin) {
+		return false, 0, nil, c.fail(CloseProtocolError, errControlTooLarge)
+	}
+	if masked == c.client {
+		// Clients must mask every frame and servers must not (RFC 6455 section 5.1).
+		return false, 0, nil, c.fail(CloseProtocolError, ErrProtocol)
+	}
+	if length < 0 || length > c.MaxMessageSize {
+		return false, 0, nil, c.fail(CloseMessageTooBig, ErrMessageTooBig)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+			return
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, op, payload, nil
+}
+
+// WriteMessage sends a single unfragmented text or binary message.
+func (c *Conn) WriteMessage(opcode int, data []byte, deadline time.Time) error {
+	return c.writeFrame(opcode, data, deadline)
+}
+
+// WritePing sends a ping control frame.
+func (c *Conn) WritePing(payload []byte, deadline time.Time) error {
+	return c.writeFrame(OpPing, payload, deadline)
+}
+
+// WriteClose sends a close frame. Further writes return ErrCloseSent.
+// CloseNoStatus and CloseAbnormal send a close frame without a code.
+func (c *Conn) WriteClose(code int, reason string) error {
+	if code == CloseNoStatus || code == CloseAbnormal {
+		return c.writeFrame(OpClose, nil, time.Now().Add(time.Second))
+	}
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	payload := make([]byte, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	copy(payload[2:], reason)
+	return c.writeFrame(OpClose, payload, time.Now().Add(time.Second))
+}
+
+func (c *Conn) writeFrame(op int, data []byte, deadline time.Time) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrCloseSent
+	}
+	if op == OpClose {
+		c.closeSent = true
+	}
+
+	hdr := make([]byte, 2, 14)
+	hdr[0] = 0x80 | byte(op)
+	switch n := len(data); {
+	case n <= 125:
+		hdr[1] = byte(n)
+	case n <= 0xFFFF:
+		hdr[1] = 126
+		hdr = binary.BigEndian.AppendUint16(hdr, uint16(n))
+	default:
+		hdr[1] = 127
+		hdr = binary.BigEndian.AppendUint64(hdr, uint64(n))
+	}
+
+	frame := hdr
+	if c.client {
+		var mask [4]byte
+		rand.Read(mask[:])
+		frame[1] |= 0x80
+		frame = append(frame, mask[:]...)
+		masked := append([]byte(nil), data...)
+		maskBytes(mask, masked)
+		data = masked
+	}
+
+	c.conn.SetWriteDeadline(deadline)
+	if _, err := c.conn.Write(append(frame, data...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i%4]
+	}
+}
+
+// Close closes the underlying network connection without a close frame.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
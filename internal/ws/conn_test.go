@@ -0,0 +1,170 @@
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455 section 1.3.
+	if got := AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("unexpected accept key %q", got)
+	}
+}
+
+// echoServer upgrades every request and echoes messages until the peer closes.
+func echoServer(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			op, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			c.WriteMessage(op, data, time.Now().Add(time.Second))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestEchoRoundTrip(t *testing.T) {
+	c, err := Dial(echoServer(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, size := range []int{0, 10, 125, 126, 1000, 65535, 65536} {
+		msg := bytes.Repeat([]byte("x"), size)
+		if err := c.WriteMessage(OpBinary, msg, time.Now().Add(time.Second)); err != nil {
+			t.Fatalf("write %d bytes: %v", size, err)
+		}
+		op, got, err := c.ReadMessage()
+		if err != nil {
+			t.Fatalf("read %d bytes: %v", size, err)
+		}
+		if op != OpBinary || !bytes.Equal(got, msg) {
+			t.Fatalf("size %d: got op %d len %d", size, op, len(got))
+		}
+	}
+}
+
+func TestPingIsAnsweredWithPong(t *testing.T) {
+	c, err := Dial(echoServer(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	pong := make(chan string, 1)
+	c.PongHandler = func(p []byte) { pong <- string(p) }
+	if err := c.WritePing([]byte("hello"), time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	// Pongs are delivered while reading; follow up with a message to read.
+	c.WriteMessage(OpText, []byte("after"), time.Now().Add(time.Second))
+	if _, data, err := c.ReadMessage(); err != nil || string(data) != "after" {
+		t.Fatalf("read: %q %v", data, err)
+	}
+	select {
+	case p := <-pong:
+		if p != "hello" {
+			t.Errorf("pong payload %q", p)
+		}
+	default:
+		t.Error("no pong received")
+	}
+}
+
+func TestCloseHandshake(t *testing.T) {
+	c, err := Dial(echoServer(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.WriteClose(CloseNormal, "bye"); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = c.ReadMessage()
+	var ce *CloseError
+	if !errors.As(err, &ce) || ce.Code != CloseNormal {
+		t.Errorf("expected close echo with 1000, got %v", err)
+	}
+	if err := c.WriteMessage(OpText, []byte("late"), time.Now().Add(time.Second)); !errors.Is(err, ErrCloseSent) {
+		t.Errorf("expected ErrCloseSent, got %v", err)
+	}
+}
+
+func TestCloseWithoutCodeIsEchoedWithoutCode(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+	server := &Conn{conn: a, br: bufio.NewReader(a), MaxMessageSize: DefaultMaxMessageSize}
+	client := &Conn{conn: b, br: bufio.NewReader(b), client: true}
+
+	go client.writeFrame(OpClose, nil, time.Now().Add(time.Second))
+	errc := make(chan error, 1)
+	go func() {
+		_, _, err := server.ReadMessage()
+		errc <- err
+	}()
+
+	// The echo must not carry 1005, which may not be sent on the wire.
+	echo := make([]byte, 2)
+	b.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := io.ReadFull(b, echo); err != nil {
+		t.Fatal(err)
+	}
+	if echo[0] != 0x80|OpClose || echo[1] != 0 {
+		t.Errorf("expected an empty close frame, got % x", echo)
+	}
+	var ce *CloseError
+	if err := <-errc; !errors.As(err, &ce) || ce.Code != CloseNoStatus {
+		t.Errorf("expected CloseError with 1005, got %v", err)
+	}
+}
+
+func TestUnmaskedClientFrameIsRejected(t *testing.T) {
+	c, err := Dial(echoServer(t), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// Pretend to be a server-side Conn so the frame goes out unmasked.
+	c.client = false
+	c.WriteMessage(OpText, []byte("bad"), time.Now().Add(time.Second))
+	c.client = true
+
+	_, _, err = c.ReadMessage()
+	var ce *CloseError
+	if !errors.As(err, &ce) || ce.Code != CloseProtocolError {
+		t.Errorf("expected protocol error close, got %v", err)
+	}
+}
+
+func TestUpgradeRequiresWebSocketHeaders(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	res := httptest.NewRecorder()
+	if _, err := Upgrade(res, req); !errors.Is(err, ErrBadHandshake) {
+		t.Fatalf("expected ErrBadHandshake, got %v", err)
+	}
+	if res.Code != http.StatusUpgradeRequired {
+		t.Errorf("expected 426, got %d", res.Code)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// The methods in this file call admin routes and need Options.AdminToken.
+// Without it the server answers with an error matching ErrUnauthorized, or
+// ErrForbidden if it has no admin token configured.
+
+// EraseUser deletes everything the server holds about a user and returns
+// the erasure receipt.
+func (c *Client) EraseUser(ctx context.Context, userID string) (ErasureReceipt, error) {
+	var receipt ErasureReceipt
+	err := c.getJSON(ctx, request{method: http.MethodDelete, path: v1("users", userID), admin: true}, &receipt)
+	return receipt, err
+}
+
+// UserDataExport returns the signed zip archive of everything held about a
+// user. The caller must close it.
+func (c *Client) UserDataExport(ctx context.Context, userID string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: v1("users", userID, "data-export"), admin: true})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ErasureLedger is the erasure ledger with its verification status.
+type ErasureLedger struct {
+	Verified bool             `json:"verified"`
+	Error    string           `json:"error,omitempty"`
+	Receipts []ErasureReceipt `json:"receipts"`
+}
+
+// Erasures returns every erasure receipt, oldest first.
+func (c *Client) Erasures(ctx context.Context) (ErasureLedger, error) {
+	var ledger ErasureLedger
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "erasures"), admin: true}, &ledger)
+	return ledger, err
+}
+
+// CreateWebhookRequest describes a webhook subscription to create.
+type CreateWebhookRequest struct {
+	URL        string      `json:"url"`
+	EventTypes []EventType `json:"eventTypes"` // Empty subscribes to all event types
+	Secret     string      `json:"secret"`     // Used to sign deliveries
+}
+
+// CreateWebhook registers a webhook subscription.
+func (c *Client) CreateWebhook(ctx context.Context, sub CreateWebhookRequest) (WebhookSubscription, error) {
+	req, err := jsonRequest(http.MethodPost, v1("admin", "webhooks"), sub)
+	if err != nil {
+		return WebhookSubscription{}, err
+	}
+	req.admin = true
+	var created WebhookSubscription
+	err = c.getJSON(ctx, req, &created)
+	return created, err
+}
+
+// ListWebhooks returns every webhook subscription.
+func (c *Client) ListWebhooks(ctx context.Context) ([]WebhookSubscription, error) {
+	var subs []WebhookSubscription
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "webhooks"), admin: true}, &subs)
+	return subs, err
+}
+
+// GetWebhook returns a webhook subscription.
+func (c *Client) GetWebhook(ctx context.Context, subID string) (WebhookSubscription, error) {
+	var sub WebhookSubscription
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "webhooks", subID), admin: true}, &sub)
+	return sub, err
+}
+
+// DeleteWebhook deletes a webhook subscription.
+func (c *Client) DeleteWebhook(ctx context.Context, subID string) error {
+	_, err := c.exec(ctx, request{method: http.MethodDelete, path: v1("admin", "webhooks", subID), admin: true})
+	return err
+}
+
+// WebhookDeliveriesOptions filters WebhookDeliveries.
+type WebhookDeliveriesOptions struct {
+	FailedOnly bool
+	Limit      int // 0 returns every logged attempt
+}
+
+// WebhookDeliveries returns the logged delivery attempts of a subscription.
+func (c *Client) WebhookDeliveries(ctx context.Context, subID string, opts WebhookDeliveriesOptions) ([]WebhookAttempt, error) {
+	q := url.Values{}
+	if opts.FailedOnly {
+		q.Set("failed", "true")
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	var attempts []WebhookAttempt
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "webhooks", subID, "deliveries"), query: q, admin: true}, &attempts)
+	return attempts, err
+}
+
+// DeadLetters returns deliveries that exhausted their retries.
+func (c *Client) DeadLetters(ctx context.Context) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "webhooks", "dead-letters"), admin: true}, &deliveries)
+	return deliveries, err
+}
+
+// Redeliver requeues a dead-lettered delivery.
+func (c *Client) Redeliver(ctx context.Context, deliveryID string) error {
+	_, err := c.exec(ctx, request{method: http.MethodPost, path: v1("admin", "webhooks", "dead-letters", deliveryID, "redeliver"), admin: true})
+	return err
+}
+
+// Aliases returns every replaced asset ID with the ID replacing it.
+func (c *Client) Aliases(ctx context.Context) ([]AssetAlias, error) {
+	var result []AssetAlias
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "catalog", "aliases"), admin: true}, &result)
+	return result, err
+}
+
+// MigrateAliases moves favorites of replaced assets onto their replacements.
+func (c *Client) MigrateAliases(ctx context.Context) (AliasMigration, error) {
+	var res AliasMigration
+	err := c.getJSON(ctx, request{method: http.MethodPost, path: v1("admin", "catalog", "migrate-aliases"), admin: true}, &res)
+	return res, err
+}
+
+// Orphans reports favorited assets that have left the catalog.
+func (c *Client) Orphans(ctx context.Context) ([]OrphanReport, error) {
+	var report []OrphanReport
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "orphans"), admin: true}, &report)
+	return report, err
+}
+
+// OrphanArchive returns favorites archived because their asset left the
+// catalog.
+func (c *Client) OrphanArchive(ctx context.Context) ([]ArchivedFavorite, error) {
+	var archived []ArchivedFavorite
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("admin", "orphans", "archive"), admin: true}, &archived)
+	return archived, err
+}
+
+// SweepOrphans runs an orphan sweep now.
+func (c *Client) SweepOrphans(ctx context.Context) (SweepResult, error) {
+	var res SweepResult
+	err := c.getJSON(ctx, request{method: http.MethodPost, path: v1("admin", "orphans", "sweep"), admin: true}, &res)
+	return res, err
+}
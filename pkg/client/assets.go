@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownAssetType is returned when an asset's JSON matches none of the
+// asset types.
+var ErrUnknownAssetType = errors.New("unknown asset type")
+
+// assetFields holds the union of every asset type's JSON fields, which the
+// server writes without a type tag.
+type assetFields struct {
+	ID          string
+	Name        string
+	Description string
+	ChartType   *string
+	DataSource  string
+	Metric      *string
+	Value       string
+	Segment     *string
+	Size        int
+}
+
+// DecodeAsset decodes an asset as written by the server into a
+// Chart, Insight or Audience. typ is the asset type
+// where the response gives one; otherwise the type is recognised by its
+// type-specific fields. JSON null decodes to a nil Asset.
+func DecodeAsset(data []byte, typ string) (Asset, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	var f assetFields
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	base := AssetBase{ID: f.ID, Name: f.Name, Description: f.Description}
+	if typ == "" {
+		switch {
+		case f.ChartType != nil:
+			typ = TypeChart
+		case f.Metric != nil:
+			typ = TypeInsight
+		case f.Segment != nil:
+			typ = TypeAudience
+		}
+	}
+	switch typ {
+	case TypeChart:
+		return Chart{AssetBase: base, ChartType: deref(f.ChartType), DataSource: f.DataSource}, nil
+	case TypeInsight:
+		return Insight{AssetBase: base, Metric: deref(f.Metric), Value: f.Value}, nil
+	case TypeAudience:
+		return Audience{AssetBase: base, Segment: deref(f.Segment), Size: f.Size}, nil
+	}
+	return nil, fmt.Errorf("%w %q for asset %s", ErrUnknownAssetType, typ, f.ID)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// CatalogAsset is an entry of ListAssets.
+type CatalogAsset struct {
+	Asset Asset
+	// FavoriteCount is nil unless the server's store keeps a popularity index.
+	FavoriteCount *int
+	// IsFavorite and FavoriteDescription are only set with ForUser.
+	IsFavorite          bool
+	FavoriteDescription string
+}
+
+func (a *CatalogAsset) UnmarshalJSON(data []byte) error {
+	var extra struct {
+		FavoriteCount       *int   `json:"favoriteCount"`
+		IsFavorite          bool   `json:"isFavorite"`
+		FavoriteDescription string `json:"favoriteDescription"`
+	}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	asset, err := DecodeAsset(data, "")
+	if err != nil {
+		return err
+	}
+	*a = CatalogAsset{
+		Asset:               asset,
+		FavoriteCount:       extra.FavoriteCount,
+		IsFavorite:          extra.IsFavorite,
+		FavoriteDescription: extra.FavoriteDescription,
+	}
+	return nil
+}
+
+// RankedAsset is an asset with its score, as returned by PopularAssets,
+// TrendingAssets and Recommendations.
+type RankedAsset struct {
+	Asset         Asset
+	Type          string
+	FavoriteCount int     // Popular and trending assets only
+	Score         float64 // Time-decayed score for trending, similarity for recommendations
+}
+
+func (a *RankedAsset) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Asset         json.RawMessage `json:"asset"`
+		Type          string          `json:"type"`
+		FavoriteCount int             `json:"favoriteCount"`
+		Score         float64         `json:"score"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	asset, err := DecodeAsset(wire.Asset, wire.Type)
+	if err != nil {
+		return err
+	}
+	*a = RankedAsset{Asset: asset, Type: wire.Type, FavoriteCount: wire.FavoriteCount, Score: wire.Score}
+	return nil
+}
+
+// ListAssetsOptions filters ListAssets.
+type ListAssetsOptions struct {
+	ForUser string // Mark the assets this user has favorited
+}
+
+// ListAssets returns the whole catalog.
+func (c *Client) ListAssets(ctx context.Context, opts ListAssetsOptions) ([]CatalogAsset, error) {
+	q := url.Values{}
+	if opts.ForUser != "" {
+		q.Set("forUser", opts.ForUser)
+	}
+	var assets []CatalogAsset
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("assets"), query: q}, &assets)
+	return assets, err
+}
+
+// RankingOptions filters PopularAssets, TrendingAssets and Recommendations.
+// Zero values use the server defaults.
+type RankingOptions struct {
+	Limit int
+	Type  string // TypeChart, TypeInsight or TypeAudience
+
+	// Trending only.
+	Window   time.Duration
+	HalfLife time.Duration
+
+	// Recommendations only: "jaccard" or "cosine".
+	Metric string
+}
+
+func (o RankingOptions) query() url.Values {
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Type != "" {
+		q.Set("type", o.Type)
+	}
+	if o.Window > 0 {
+		q.Set("window", o.Window.String())
+	}
+	if o.HalfLife > 0 {
+		q.Set("halfLife", o.HalfLife.String())
+	}
+	if o.Metric != "" {
+		q.Set("metric", o.Metric)
+	}
+	return q
+}
+
+// PopularAssets returns the most favorited assets.
+func (c *Client) PopularAssets(ctx context.Context, opts RankingOptions) ([]RankedAsset, error) {
+	var assets []RankedAsset
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("assets", "popular"), query: opts.query()}, &assets)
+	return assets, err
+}
+
+// TrendingAssets returns the assets favorited most in the recent window.
+func (c *Client) TrendingAssets(ctx context.Context, opts RankingOptions) ([]RankedAsset, error) {
+	var assets []RankedAsset
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("assets", "trending"), query: opts.query()}, &assets)
+	return assets, err
+}
+
+// Recommendations returns assets similar to the user's favorites.
+func (c *Client) Recommendations(ctx context.Context, userID string, opts RankingOptions) ([]RankedAsset, error) {
+	var assets []RankedAsset
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "recommendations"), query: opts.query()}, &assets)
+	return assets, err
+}
@@ -0,0 +1,282 @@
+// Package client is the Go SDK for the favorites REST API. It covers every
+// /v1 route except the WebSocket sync endpoint, plus /healthz and /graphql.
+// It decodes catalog assets into Chart, Insight and Audience, pages through
+// history with iterators and retries transient failures with exponential
+// backoff. The wire types are re-exported here, so callers need not import
+// the server's internal packages.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Errors matched by errors.Is against an *Error from the server.
+var (
+	ErrBadRequest     = errors.New("bad request")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrNotImplemented = errors.New("not implemented by server")
+)
+
+// Error is a non-2xx response from the server.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string // The plain-text body sent by the server
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// Is lets callers match errors by status with the Err values above.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrNotImplemented:
+		return e.StatusCode == http.StatusNotImplemented
+	}
+	return false
+}
+
+// Options configures a Client. Zero values get the defaults noted.
+type Options struct {
+	HTTPClient  *http.Client  // Default: http.Client with a 30s timeout
+	AdminToken  string        // Sent as a bearer token to admin routes
+	Actor       string        // Sent as X-Actor to attribute writes to someone other than the user; requires AdminToken
+	MaxAttempts int           // Attempts per request, including the first (default 4)
+	BaseBackoff time.Duration // Delay before the first retry (default 200ms)
+	MaxBackoff  time.Duration // Upper bound on retry delay (default 5s)
+}
+
+func (o *Options) setDefaults() {
+	if o.HTTPClient == nil {
+		o.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 4
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 200 * time.Millisecond
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 5 * time.Second
+	}
+}
+
+// Client calls the favorites API. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	opts    Options
+}
+
+// New returns a client for the server at baseURL, e.g.
+// "http://localhost:8080". Requests go to the /v1 routes.
+func New(baseURL string, opts Options) (*Client, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+	opts.setDefaults()
+	return &Client{baseURL: baseURL, opts: opts}, nil
+}
+
+// request describes one API call.
+type request struct {
+	method      string
+	path        string // Escaped path below the base URL, including /v1
+	query       url.Values
+	body        []byte
+	contentType string
+	header      http.Header
+	admin       bool // Send the admin token
+	accept      int  // A non-2xx status to return as a response, not an error
+}
+
+// v1 builds a /v1 path from segments, escaping each one.
+func v1(segments ...string) string {
+	var b strings.Builder
+	b.WriteString("/v1")
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(s))
+	}
+	return b.String()
+}
+
+// jsonRequest returns a request with body encoded as JSON.
+func jsonRequest(method, path string, body any) (request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return request{}, err
+	}
+	return request{method: method, path: path, body: b, contentType: "application/json"}, nil
+}
+
+// do sends req, retrying as described on retryable, and returns the
+// response if its status is 2xx or req.accept. The caller must close its
+// body.
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, req)
+		status := 0
+		if err == nil {
+			if resp.StatusCode < 300 || resp.StatusCode == req.accept {
+				return resp, nil
+			}
+			status = resp.StatusCode
+			err = responseError(req, resp)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if attempt >= c.opts.MaxAttempts || !retryable(req.method, status) {
+			return nil, err
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				delay = min(d, c.opts.MaxBackoff)
+			}
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	r, err := http.NewRequestWithContext(ctx, req.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range req.header {
+		r.Header[k] = v
+	}
+	if req.contentType != "" {
+		r.Header.Set("Content-Type", req.contentType)
+	}
+	if c.opts.Actor != "" {
+		r.Header.Set("X-Actor", c.opts.Actor)
+	}
+	// The server ignores X-Actor from callers that are not admins.
+	if (req.admin || c.opts.Actor != "") && c.opts.AdminToken != "" {
+		r.Header.Set("Authorization", "Bearer "+c.opts.AdminToken)
+	}
+	return c.opts.HTTPClient.Do(r)
+}
+
+// responseError reads an error response and closes its body.
+func responseError(req request, resp *http.Response) error {
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &Error{
+		Method:     req.method,
+		Path:       req.path,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(msg)),
+	}
+}
+
+// retryable reports whether a failed attempt should be retried. Status 0
+// means the request itself failed. 429 is always retried, since the server
+// did not act on the request; other failures only for idempotent methods,
+// so a POST that reached the server is never applied twice. 501 is
+// permanent and never retried.
+func retryable(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	if status != 0 && (status < 500 || status == http.StatusNotImplemented) {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.opts.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= c.opts.MaxBackoff {
+			return c.opts.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs) * time.Second, true
+}
+
+// getJSON sends req and decodes the JSON response into out.
+func (c *Client) getJSON(ctx context.Context, req request, out any) error {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// exec sends req and discards the response body, returning the status.
+func (c *Client) exec(ctx context.Context, req request) (int, error) {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,405 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"my-solution/internal/api"
+	"my-solution/internal/catalog"
+	"my-solution/internal/events"
+	"my-solution/internal/export"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/store"
+
+	"github.com/gorilla/mux"
+)
+
+const adminToken = "secret"
+
+// setupServer serves the real API with history and events, wrapped in
+// middleware if given, and returns a client for it.
+func setupServer(t *testing.T, opts Options, middleware ...mux.MiddlewareFunc) *Client {
+	t.Helper()
+	catalog.Initialize()
+	catalog.Global.AddAsset("c1", &models.Chart{AssetBase: models.AssetBase{ID: "c1", Name: "Chart"}, ChartType: "bar", DataSource: "db"})
+	catalog.Global.AddAsset("i1", models.Insight{AssetBase: models.AssetBase{ID: "i1", Name: "Insight"}, Metric: "growth", Value: "15%"})
+	catalog.Global.AddAsset("a1", models.Audience{AssetBase: models.AssetBase{ID: "a1", Name: "Audience"}, Segment: "new", Size: 42})
+
+	bus := events.NewBus(0)
+	h := history.NewStore(events.NewStore(store.NewMemoryStore(), bus))
+	a := &api.API{Store: h, Events: bus, History: h, AdminToken: adminToken}
+	r := mux.NewRouter()
+	r.Use(middleware...)
+	a.RegisterHandlers(r)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestFavorites(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx := context.Background()
+
+	for _, id := range []string{"c1", "i1", "a1"} {
+		if err := c.AddFavorite(ctx, "u:1", id, "note "+id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.AddFavorite(ctx, "u:1", "c1", ""); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+	if err := c.AddFavorite(ctx, "u:1", "missing", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	favs, err := c.ListFavorites(ctx, "u:1", ListFavoritesOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(favs) != 3 {
+		t.Fatalf("expected 3 favorites, got %+v", favs)
+	}
+	byID := make(map[string]models.Asset)
+	for _, f := range favs {
+		byID[f.AssetID] = f.Asset
+	}
+	if chart, ok := byID["c1"].(models.Chart); !ok || chart.ChartType != "bar" || chart.DataSource != "db" {
+		t.Errorf("expected chart, got %#v", byID["c1"])
+	}
+	if insight, ok := byID["i1"].(models.Insight); !ok || insight.Value != "15%" {
+		t.Errorf("expected insight, got %#v", byID["i1"])
+	}
+	if audience, ok := byID["a1"].(models.Audience); !ok || audience.Size != 42 {
+		t.Errorf("expected audience, got %#v", byID["a1"])
+	}
+
+	created, err := c.PutFavorite(ctx, "u:1", "c1", "put")
+	if err != nil || created {
+		t.Errorf("expected update, got created=%v, %v", created, err)
+	}
+	if err := c.EditFavorite(ctx, "u:1", "i1", "edited"); err != nil {
+		t.Fatal(err)
+	}
+	fav, err := c.GetFavorite(ctx, "u:1", "i1")
+	if err != nil || fav.Description != "edited" {
+		t.Errorf("unexpected favorite %+v, %v", fav, err)
+	}
+
+	if err := c.RemoveFavorite(ctx, "u:1", "a1", RemoveFavoriteOptions{Strict: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveFavorite(ctx, "u:1", "a1", RemoveFavoriteOptions{Strict: true}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound on strict remove, got %v", err)
+	}
+	if ok, err := c.IsFavorite(ctx, "u:1", "a1"); err != nil || ok {
+		t.Errorf("expected a1 not favorited, got %v, %v", ok, err)
+	}
+
+	trash, err := c.ListTrash(ctx, "u:1")
+	if err != nil || len(trash) != 1 {
+		t.Fatalf("unexpected trash %+v, %v", trash, err)
+	}
+	if _, ok := trash[0].Asset.(models.Audience); !ok {
+		t.Errorf("expected trashed audience, got %#v", trash[0].Asset)
+	}
+	if err := c.RestoreFavorite(ctx, "u:1", "a1"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := c.IsFavorite(ctx, "u:1", "a1"); err != nil || !ok {
+		t.Errorf("expected a1 restored, got %v, %v", ok, err)
+	}
+
+	d, err := c.Dashboard(ctx, "u:1", 0)
+	if err != nil || d.Total != 3 {
+		t.Errorf("unexpected dashboard %+v, %v", d, err)
+	}
+}
+
+func TestListAssets(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx := context.Background()
+	c.AddFavorite(ctx, "u1", "i1", "mine")
+
+	assets, err := c.ListAssets(ctx, ListAssetsOptions{ForUser: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 3 {
+		t.Fatalf("expected 3 assets, got %+v", assets)
+	}
+	for _, a := range assets {
+		if got, want := a.IsFavorite, a.Asset.GetID() == "i1"; got != want {
+			t.Errorf("%s: isFavorite %v, want %v", a.Asset.GetID(), got, want)
+		}
+		if a.FavoriteCount == nil {
+			t.Errorf("%s: expected a favorite count", a.Asset.GetID())
+		}
+		if a.Asset.GetID() == "i1" && a.FavoriteDescription != "mine" {
+			t.Errorf("unexpected description %q", a.FavoriteDescription)
+		}
+	}
+
+	popular, err := c.PopularAssets(ctx, RankingOptions{Limit: 1})
+	if err != nil || len(popular) != 1 {
+		t.Fatalf("unexpected popular assets %+v, %v", popular, err)
+	}
+	if _, ok := popular[0].Asset.(models.Insight); !ok || popular[0].FavoriteCount != 1 {
+		t.Errorf("expected the insight, got %+v", popular[0])
+	}
+}
+
+func TestDecodeAsset(t *testing.T) {
+	a, err := DecodeAsset([]byte(`{"ID":"x","Name":"X","ChartType":"pie"}`), "")
+	if c, ok := a.(Chart); err != nil || !ok || c.ChartType != "pie" {
+		t.Errorf("expected chart, got %#v, %v", a, err)
+	}
+	// An empty type-specific field is still recognised by the type hint.
+	a, err = DecodeAsset([]byte(`{"ID":"y","Name":"Y"}`), TypeAudience)
+	if _, ok := a.(Audience); err != nil || !ok {
+		t.Errorf("expected audience, got %#v, %v", a, err)
+	}
+	if a, err := DecodeAsset([]byte(`null`), ""); a != nil || err != nil {
+		t.Errorf("expected nil asset, got %#v, %v", a, err)
+	}
+	if _, err := DecodeAsset([]byte(`{"ID":"z"}`), ""); !errors.Is(err, ErrUnknownAssetType) {
+		t.Errorf("expected ErrUnknownAssetType, got %v", err)
+	}
+}
+
+func TestHistoryIterator(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx := context.Background()
+	for _, id := range []string{"c1", "i1", "a1"} {
+		c.AddFavorite(ctx, "u1", id, "")
+	}
+	c.RemoveFavorite(ctx, "u1", "c1", RemoveFavoriteOptions{})
+
+	var seqs []uint64
+	for e, err := range c.History(ctx, "u1", 3) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, e.Seq)
+	}
+	if len(seqs) != 4 || seqs[0] != 4 || seqs[3] != 1 {
+		t.Errorf("expected seqs 4..1, got %v", seqs)
+	}
+
+	n := 0
+	for range c.History(ctx, "u1", 1) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected to stop after 2 entries, got %d", n)
+	}
+
+	entry, err := c.Undo(ctx, "u1")
+	if err != nil || entry.Action != HistoryRestored {
+		t.Errorf("unexpected undo %+v, %v", entry, err)
+	}
+}
+
+func TestActor(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		opts Options
+		want string
+	}{
+		{Options{Actor: "support", AdminToken: adminToken}, "support"},
+		{Options{Actor: "support"}, "u1"}, // Ignored by the server without the token
+	} {
+		c := setupServer(t, tc.opts)
+		if err := c.AddFavorite(ctx, "u1", "c1", ""); err != nil {
+			t.Fatal(err)
+		}
+		for e, err := range c.History(ctx, "u1", 0) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Actor != tc.want {
+				t.Errorf("%+v: expected actor %q, got %q", tc.opts, tc.want, e.Actor)
+			}
+		}
+	}
+}
+
+// failFirst answers the first n requests with status, asking clients to
+// retry 429s immediately.
+func failFirst(n int32, status int, calls *atomic.Int32) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+				http.Error(w, "try again", status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	opts := Options{BaseBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	c := setupServer(t, opts, failFirst(2, http.StatusServiceUnavailable, &calls))
+	if _, err := c.ListFavorites(ctx, "u1", ListFavoritesOptions{}); err != nil || calls.Load() != 3 {
+		t.Errorf("expected success on the third attempt, got %d calls, %v", calls.Load(), err)
+	}
+
+	// POST is not retried on 5xx: the first attempt may have been applied.
+	calls.Store(0)
+	c = setupServer(t, opts, failFirst(1, http.StatusServiceUnavailable, &calls))
+	err := c.AddFavorite(ctx, "u1", "c1", "")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("expected one failed attempt, got %d calls, %v", calls.Load(), err)
+	}
+
+	// 429 is retried for every method.
+	calls.Store(0)
+	c = setupServer(t, opts, failFirst(1, http.StatusTooManyRequests, &calls))
+	if err := c.AddFavorite(ctx, "u1", "c1", ""); err != nil || calls.Load() != 2 {
+		t.Errorf("expected success on the second attempt, got %d calls, %v", calls.Load(), err)
+	}
+
+	// Attempts are bounded.
+	calls.Store(0)
+	c = setupServer(t, opts, failFirst(100, http.StatusBadGateway, &calls))
+	if _, err := c.Health(ctx); err == nil || calls.Load() != 4 {
+		t.Errorf("expected 4 failed attempts, got %d calls, %v", calls.Load(), err)
+	}
+
+	// Context cancellation ends the retries.
+	calls.Store(0)
+	c = setupServer(t, Options{BaseBackoff: time.Hour}, failFirst(100, http.StatusBadGateway, &calls))
+	cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.ListFavorites(cctx, "u1", ListFavoritesOptions{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	c := setupServer(t, Options{})
+	if _, err := c.Orphans(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized without a token, got %v", err)
+	}
+
+	c = setupServer(t, Options{AdminToken: adminToken})
+	if _, err := c.Orphans(ctx); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("expected ErrNotImplemented without a sweeper, got %v", err)
+	}
+	if _, err := c.Aliases(ctx); err != nil {
+		t.Errorf("aliases: %v", err)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx := context.Background()
+	c.AddFavorite(ctx, "u1", "c1", "one")
+	c.AddFavorite(ctx, "u1", "a1", "two")
+
+	rows, err := c.ExportRows(ctx, "u1")
+	if err != nil || len(rows) != 2 || rows[0].Type == "" {
+		t.Fatalf("unexpected rows %+v, %v", rows, err)
+	}
+
+	f, err := c.ExportFavorites(ctx, "u1", export.CSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, _ := io.ReadAll(f)
+	f.Close()
+
+	report, err := c.ImportFavorites(ctx, "u2", bytes.NewReader(file), ImportOptions{Format: export.CSV})
+	if err != nil || report.Added != 2 {
+		t.Fatalf("unexpected report %+v, %v", report, err)
+	}
+	report, err = c.ImportFavorites(ctx, "u2", bytes.NewReader(file), ImportOptions{Format: export.CSV, Policy: export.PolicyFail})
+	if !errors.Is(err, ErrConflict) || report.Conflicts != 2 {
+		t.Errorf("expected conflicts, got %+v, %v", report, err)
+	}
+}
+
+func TestFavoriteEvents(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.AddFavorite(ctx, "u1", "c1", "first")
+
+	received := make(chan events.Event)
+	go func() {
+		for e, err := range c.FavoriteEvents(ctx, "u1", 0) {
+			if err != nil {
+				return
+			}
+			received <- e
+		}
+	}()
+
+	next := func() events.Event {
+		t.Helper()
+		select {
+		case e := <-received:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("no event received")
+		}
+		return events.Event{}
+	}
+	// Without a last event ID the replay buffer comes first.
+	if e := next(); e.Seq != 1 || e.AssetID != "c1" {
+		t.Errorf("unexpected replayed event %+v", e)
+	}
+	c.AddFavorite(ctx, "u1", "i1", "second")
+	if e := next(); e.Type != events.FavoriteAdded || e.AssetID != "i1" || e.Seq != 2 {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestGraphQL(t *testing.T) {
+	c := setupServer(t, Options{})
+	ctx := context.Background()
+	c.AddFavorite(ctx, "u1", "c1", "note")
+
+	var data struct {
+		User struct {
+			Favorites struct {
+				TotalCount int
+			}
+		}
+	}
+	if err := c.GraphQL(ctx, `query($id: ID!) { user(id: $id) { favorites { totalCount } } }`, map[string]any{"id": "u1"}, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data.User.Favorites.TotalCount != 1 {
+		t.Errorf("unexpected data %+v", data)
+	}
+
+	var gqlErrs GraphQLErrors
+	if err := c.GraphQL(ctx, `{ nope }`, nil, nil); !errors.As(err, &gqlErrs) {
+		t.Errorf("expected GraphQLErrors, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrEventsReset is yielded by FavoriteEvents when the server could not
+// replay every event since lastEventID. The client should re-fetch the
+// user's favorites; the stream continues with new events.
+var ErrEventsReset = errors.New("favorite events were lost, re-fetch favorites")
+
+// FavoriteEvents streams changes to the user's favorites until ctx is done
+// or the server ends the stream. Pass the Seq of the last event seen to
+// resume after it, or 0 to start with the events the server still buffers.
+// The stream is not subject to Options.HTTPClient's timeout and is not
+// retried; reconnect with the last Seq to resume.
+func (c *Client) FavoriteEvents(ctx context.Context, userID string, lastEventID uint64) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		req := request{method: http.MethodGet, path: v1("users", userID, "favorites", "events"), header: http.Header{}}
+		req.header.Set("Accept", "text/event-stream")
+		if lastEventID > 0 {
+			req.header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+		}
+
+		streaming := *c
+		hc := *c.opts.HTTPClient
+		hc.Timeout = 0
+		streaming.opts.HTTPClient = &hc
+		streaming.opts.MaxAttempts = 1
+		resp, err := streaming.do(ctx, req)
+		if err != nil {
+			yield(Event{}, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		var eventType, data string
+		lines := bufio.NewScanner(resp.Body)
+		for lines.Scan() {
+			line := lines.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				eventType = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && data != "":
+				// End of an event; comments and ids need no handling.
+				var e Event
+				err := ErrEventsReset
+				if eventType != "reset" {
+					if err = json.Unmarshal([]byte(data), &e); err != nil {
+						err = fmt.Errorf("decoding event: %w", err)
+					}
+				}
+				if !yield(e, err) {
+					return
+				}
+				eventType, data = "", ""
+			}
+		}
+		if err := lines.Err(); err != nil && ctx.Err() == nil {
+			yield(Event{}, err)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"my-solution/internal/export"
+	"my-solution/pkg/health"
+)
+
+// favoriteJSON is the wire form of FavoriteWithAsset and
+// TrashedFavorite, with the asset left to DecodeAsset.
+type favoriteJSON struct {
+	AssetID     string          `json:"assetId"`
+	Description string          `json:"description"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	DeletedAt   time.Time       `json:"deletedAt"`
+	ExpiresAt   time.Time       `json:"expiresAt"`
+	Asset       json.RawMessage `json:"asset"`
+	Status      string          `json:"status"`
+}
+
+func (f favoriteJSON) favorite() (FavoriteWithAsset, error) {
+	asset, err := DecodeAsset(f.Asset, "")
+	return FavoriteWithAsset{
+		AssetID:     f.AssetID,
+		Description: f.Description,
+		CreatedAt:   f.CreatedAt,
+		Asset:       asset,
+		Status:      f.Status,
+	}, err
+}
+
+func (f favoriteJSON) trashed() (TrashedFavorite, error) {
+	asset, err := DecodeAsset(f.Asset, "")
+	return TrashedFavorite{
+		AssetID:     f.AssetID,
+		Description: f.Description,
+		CreatedAt:   f.CreatedAt,
+		DeletedAt:   f.DeletedAt,
+		ExpiresAt:   f.ExpiresAt,
+		Asset:       asset,
+		Status:      f.Status,
+	}, err
+}
+
+// Health returns the server's status and version.
+func (c *Client) Health(ctx context.Context) (health.HealthResponse, error) {
+	var h health.HealthResponse
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: "/healthz"}, &h)
+	return h, err
+}
+
+// ListFavoritesOptions filters ListFavorites.
+type ListFavoritesOptions struct {
+	// IncludeUnavailable includes favorites whose asset has left the
+	// catalog, with a nil Asset and Status "unavailable".
+	IncludeUnavailable bool
+}
+
+// ListFavorites returns all of the user's favorites with their assets.
+func (c *Client) ListFavorites(ctx context.Context, userID string, opts ListFavoritesOptions) ([]FavoriteWithAsset, error) {
+	q := url.Values{}
+	if opts.IncludeUnavailable {
+		q.Set("includeUnavailable", "true")
+	}
+	var wire []favoriteJSON
+	if err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "favorites"), query: q}, &wire); err != nil {
+		return nil, err
+	}
+	favorites := make([]FavoriteWithAsset, len(wire))
+	for i, f := range wire {
+		var err error
+		if favorites[i], err = f.favorite(); err != nil {
+			return nil, err
+		}
+	}
+	return favorites, nil
+}
+
+// GetFavorite returns one of the user's favorites. It returns an error
+// matching ErrNotFound if the asset is not favorited.
+func (c *Client) GetFavorite(ctx context.Context, userID, assetID string) (FavoriteWithAsset, error) {
+	var wire favoriteJSON
+	if err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "favorites", assetID)}, &wire); err != nil {
+		return FavoriteWithAsset{}, err
+	}
+	return wire.favorite()
+}
+
+// IsFavorite reports whether the user has favorited the asset.
+func (c *Client) IsFavorite(ctx context.Context, userID, assetID string) (bool, error) {
+	_, err := c.exec(ctx, request{method: http.MethodHead, path: v1("users", userID, "favorites", assetID)})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AddFavorite favorites an asset. It returns an error matching ErrConflict
+// if the asset is already favorited and ErrNotFound if it is not in the
+// catalog.
+func (c *Client) AddFavorite(ctx context.Context, userID, assetID, description string) error {
+	req, err := jsonRequest(http.MethodPost, v1("users", userID, "favorites"), map[string]string{
+		"assetId":     assetID,
+		"description": description,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.exec(ctx, req)
+	return err
+}
+
+// PutFavorite favorites an asset or, if it is already favorited, sets its
+// description. It reports whether the favorite was created.
+func (c *Client) PutFavorite(ctx context.Context, userID, assetID, description string) (bool, error) {
+	req, err := jsonRequest(http.MethodPut, v1("users", userID, "favorites", assetID), map[string]string{
+		"description": description,
+	})
+	if err != nil {
+		return false, err
+	}
+	status, err := c.exec(ctx, req)
+	return status == http.StatusCreated, err
+}
+
+// RemoveFavoriteOptions changes how RemoveFavorite treats missing favorites.
+type RemoveFavoriteOptions struct {
+	// Strict returns an error matching ErrNotFound if the asset is not
+	// favorited, rather than succeeding.
+	Strict bool
+}
+
+// RemoveFavorite removes an asset from the user's favorites.
+func (c *Client) RemoveFavorite(ctx context.Context, userID, assetID string, opts RemoveFavoriteOptions) error {
+	q := url.Values{}
+	if opts.Strict {
+		q.Set("strict", "true")
+	}
+	_, err := c.exec(ctx, request{method: http.MethodDelete, path: v1("users", userID, "favorites", assetID), query: q})
+	return err
+}
+
+// EditFavorite sets the description of an existing favorite.
+func (c *Client) EditFavorite(ctx context.Context, userID, assetID, description string) error {
+	req, err := jsonRequest(http.MethodPatch, v1("users", userID, "favorites", assetID), map[string]string{
+		"description": description,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.exec(ctx, req)
+	return err
+}
+
+// Dashboard returns the user's dashboard with up to limit tiles per group,
+// or the server default if limit is 0.
+func (c *Client) Dashboard(ctx context.Context, userID string, limit int) (Dashboard, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var d Dashboard
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "dashboard"), query: q}, &d)
+	return d, err
+}
+
+// HistoryPage returns one page of the user's favorites history, most
+// recent first. before is the NextBefore of the previous page, or 0 for
+// the first; limit 0 uses the server default.
+func (c *Client) HistoryPage(ctx context.Context, userID string, before uint64, limit int) (HistoryPage, error) {
+	q := url.Values{}
+	if before > 0 {
+		q.Set("before", strconv.FormatUint(before, 10))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var page HistoryPage
+	err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "favorites", "history"), query: q}, &page)
+	return page, err
+}
+
+// History iterates over the user's whole favorites history, most recent
+// first, fetching pages of pageSize entries as needed (0 uses the server
+// default). Iteration stops after the first error.
+func (c *Client) History(ctx context.Context, userID string, pageSize int) iter.Seq2[HistoryEntry, error] {
+	return func(yield func(HistoryEntry, error) bool) {
+		var before uint64
+		for {
+			page, err := c.HistoryPage(ctx, userID, before, pageSize)
+			if err != nil {
+				yield(HistoryEntry{}, err)
+				return
+			}
+			for _, e := range page.Entries {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			if page.NextBefore == 0 {
+				return
+			}
+			before = page.NextBefore
+		}
+	}
+}
+
+// Undo reverts the user's most recent change that has not been undone and
+// returns the history entry recording the revert.
+func (c *Client) Undo(ctx context.Context, userID string) (HistoryEntry, error) {
+	var e HistoryEntry
+	err := c.getJSON(ctx, request{method: http.MethodPost, path: v1("users", userID, "favorites", "undo")}, &e)
+	return e, err
+}
+
+// ListTrash returns the user's removed favorites that can still be restored.
+func (c *Client) ListTrash(ctx context.Context, userID string) ([]TrashedFavorite, error) {
+	var wire []favoriteJSON
+	if err := c.getJSON(ctx, request{method: http.MethodGet, path: v1("users", userID, "favorites", "trash")}, &wire); err != nil {
+		return nil, err
+	}
+	items := make([]TrashedFavorite, len(wire))
+	for i, f := range wire {
+		var err error
+		if items[i], err = f.trashed(); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// RestoreFavorite brings a removed favorite back from the trash.
+func (c *Client) RestoreFavorite(ctx context.Context, userID, assetID string) error {
+	_, err := c.exec(ctx, request{method: http.MethodPost, path: v1("users", userID, "favorites", "trash", assetID, "restore")})
+	return err
+}
+
+// ExportFavorites returns the user's favorites as a file in format. The
+// caller must close it.
+func (c *Client) ExportFavorites(ctx context.Context, userID string, format ExportFormat) (io.ReadCloser, error) {
+	q := url.Values{"format": {string(format)}}
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: v1("users", userID, "favorites", "export"), query: q})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ExportRows returns the user's favorites as export rows.
+func (c *Client) ExportRows(ctx context.Context, userID string) ([]ExportRow, error) {
+	f, err := c.ExportFavorites(ctx, userID, FormatNDJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []ExportRow
+	rd := export.NewReader(f, FormatNDJSON)
+	for {
+		row, err := rd.Next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ImportOptions configures ImportFavorites.
+type ImportOptions struct {
+	Format ExportFormat
+	Policy ImportPolicy // Default: skip favorites that already exist
+	DryRun bool
+}
+
+// ImportFavorites adds favorites from a file in the export format. With
+// policy fail, conflicts return the report along with an error matching
+// ErrConflict.
+func (c *Client) ImportFavorites(ctx context.Context, userID string, file io.Reader, opts ImportOptions) (ImportReport, error) {
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	q := url.Values{"format": {string(opts.Format)}}
+	if opts.Policy != "" {
+		q.Set("policy", string(opts.Policy))
+	}
+	if opts.DryRun {
+		q.Set("dryRun", "true")
+	}
+	req := request{
+		method:      http.MethodPost,
+		path:        v1("users", userID, "favorites", "import"),
+		query:       q,
+		body:        body,
+		contentType: opts.Format.ContentType(),
+		accept:      http.StatusConflict,
+	}
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	defer resp.Body.Close()
+
+	var report ImportReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return ImportReport{}, fmt.Errorf("%s %s: decoding response: %w", req.method, req.path, err)
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return report, &Error{Method: req.method, Path: req.path, StatusCode: resp.StatusCode, Message: "import conflicts with existing favorites"}
+	}
+	return report, nil
+}
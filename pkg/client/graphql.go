@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError is an error reported by a GraphQL query.
+type GraphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// GraphQLErrors are the errors of a GraphQL response. Fields without an
+// error may still have been decoded.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+	}
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// GraphQL runs a query or mutation against /graphql and decodes its data
+// into out. Errors reported in the response are returned as GraphQLErrors.
+// Queries are not retried, since they may be mutations.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	req, err := jsonRequest(http.MethodPost, "/graphql", map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := c.getJSON(ctx, req, &resp); err != nil {
+		return err
+	}
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("decoding graphql data: %w", err)
+		}
+	}
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+	return nil
+}
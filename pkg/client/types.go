@@ -0,0 +1,120 @@
+package client
+
+import (
+	"my-solution/internal/aliases"
+	"my-solution/internal/catalog"
+	"my-solution/internal/dashboard"
+	"my-solution/internal/events"
+	"my-solution/internal/export"
+	"my-solution/internal/gdpr"
+	"my-solution/internal/history"
+	"my-solution/internal/models"
+	"my-solution/internal/orphans"
+	"my-solution/internal/webhooks"
+)
+
+// The types below are the server's wire types, re-exported so that code
+// outside this module, which cannot import the internal packages, can name
+// them: declare a HistoryEntry, type-switch an Asset on Chart, and so on.
+
+// Catalog assets and favorites.
+type (
+	Asset             = models.Asset
+	AssetBase         = models.AssetBase
+	Chart             = models.Chart
+	Insight           = models.Insight
+	Audience          = models.Audience
+	FavoriteWithAsset = models.FavoriteWithAsset
+	TrashedFavorite   = models.TrashedFavorite
+)
+
+// Asset types, as used by DecodeAsset and RankingOptions.Type.
+const (
+	TypeChart    = models.TypeChart
+	TypeInsight  = models.TypeInsight
+	TypeAudience = models.TypeAudience
+)
+
+// StatusUnavailable is the Status of a favorite whose asset has left the
+// catalog.
+const StatusUnavailable = models.StatusUnavailable
+
+// Favorites history.
+type (
+	HistoryEntry  = history.Entry
+	HistoryPage   = history.Page
+	HistoryAction = history.Action
+)
+
+const (
+	HistoryAdded    = history.Added
+	HistoryRemoved  = history.Removed
+	HistoryEdited   = history.Edited
+	HistoryRestored = history.Restored
+	HistoryMigrated = history.Migrated
+)
+
+// Favorite change events.
+type (
+	Event     = events.Event
+	EventType = events.Type
+)
+
+const (
+	FavoriteAdded     = events.FavoriteAdded
+	FavoriteRemoved   = events.FavoriteRemoved
+	DescriptionEdited = events.DescriptionEdited
+)
+
+// Export and import.
+type (
+	ExportFormat    = export.Format
+	ExportRow       = export.Row
+	ImportPolicy    = export.Policy
+	ImportReport    = export.Report
+	ImportRowResult = export.RowResult
+	ImportAction    = export.Action
+)
+
+const (
+	FormatCSV    = export.CSV
+	FormatJSON   = export.JSON
+	FormatNDJSON = export.NDJSON
+
+	PolicySkip      = export.PolicySkip
+	PolicyOverwrite = export.PolicyOverwrite
+	PolicyFail      = export.PolicyFail
+
+	ImportAdd       = export.ActionAdd
+	ImportOverwrite = export.ActionOverwrite
+	ImportSkip      = export.ActionSkip
+	ImportConflict  = export.ActionConflict
+	ImportInvalid   = export.ActionInvalid
+)
+
+// Dashboards.
+type (
+	Dashboard      = dashboard.Dashboard
+	DashboardGroup = dashboard.Group
+	DashboardTile  = dashboard.Tile
+)
+
+// Admin: erasure, webhooks, aliases and orphans.
+type (
+	ErasureReceipt      = gdpr.Receipt
+	WebhookSubscription = webhooks.Subscription
+	WebhookAttempt      = webhooks.Attempt
+	WebhookDelivery     = webhooks.Delivery
+	DeliveryState       = webhooks.DeliveryState
+	AssetAlias          = catalog.Alias
+	AliasMigration      = aliases.Result
+	OrphanReport        = orphans.Report
+	ArchivedFavorite    = orphans.ArchivedFavorite
+	SweepResult         = orphans.SweepResult
+)
+
+const (
+	DeliveryPending   = webhooks.StatePending
+	DeliverySucceeded = webhooks.StateSucceeded
+	DeliveryDead      = webhooks.StateDead
+)